@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go-open-llm-vtuber/internal/config"
@@ -27,7 +31,36 @@ func main() {
 	srv := server.NewServer(cfg)
 	srv.SetupRoutes(r)
 
-	// 启动服务器
-	log.Printf("服务器启动在 %s:%d", cfg.Host, cfg.Port)
-	log.Fatal(http.ListenAndServe(cfg.Host+":"+cfg.Port, r))
-}
\ No newline at end of file
+	httpServer := &http.Server{
+		Addr:    cfg.Host + ":" + cfg.Port,
+		Handler: r,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("服务器启动在 %s:%s", cfg.Host, cfg.Port)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErr:
+		log.Fatalf("服务器启动失败: %v", err)
+	case <-ctx.Done():
+		log.Println("收到关闭信号，开始优雅关闭...")
+	}
+
+	shutdownTimeout := time.Duration(cfg.System.ShutdownTimeoutSeconds) * time.Second
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP服务器关闭失败: %v", err)
+	}
+	srv.Shutdown(shutdownCtx)
+	log.Println("服务器已关闭")
+}
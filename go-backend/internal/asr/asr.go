@@ -0,0 +1,89 @@
+// Package asr provides a pluggable streaming speech-recognition subsystem.
+//
+// A StreamingRecognizer is started once per client connection. Audio chunks
+// are pushed in as they arrive off the WebSocket, and partial/final results
+// are read back from the Session's Results channel on a separate goroutine.
+package asr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MaxUtteranceDuration bounds how long a single utterance may stay open
+// before the session is force-finalized, matching the guard used by most
+// short-utterance ASR endpoints.
+const MaxUtteranceDuration = 60 * time.Second
+
+// Params carries the per-session configuration needed to start recognition.
+type Params struct {
+	ClientUID  string
+	SampleRate int
+	Language   string
+}
+
+// Result is a single partial or final recognition result.
+type Result struct {
+	Text      string
+	IsFinal   bool
+	Err       error
+	Timestamp time.Time
+}
+
+// Session represents one in-progress recognition stream for a single
+// utterance (or a sequence of utterances, depending on the driver).
+type Session interface {
+	// Push forwards one chunk of raw PCM audio to the recognizer.
+	Push(chunk []byte) error
+	// Results returns the channel partial/final results are delivered on.
+	// The channel is closed after Close is called and the driver has
+	// finished flushing any pending result.
+	Results() <-chan Result
+	// Close tears down the session and releases any underlying
+	// connection or subprocess.
+	Close() error
+	// Done returns a channel that is closed once the session has ended,
+	// whether via an explicit Close or because the driver force-ended it
+	// itself (e.g. the max-utterance guard firing). Manager uses this to
+	// evict a self-ended session instead of handing it out again.
+	Done() <-chan struct{}
+}
+
+// StreamingRecognizer is implemented by every ASR driver.
+type StreamingRecognizer interface {
+	// Start opens a new recognition session for one client.
+	Start(ctx context.Context, params Params) (Session, error)
+	// Name returns the driver name as used in ASRConfig.Provider.
+	Name() string
+}
+
+// Factory builds a StreamingRecognizer from the driver-specific config map
+// pulled out of ASRConfig.
+type Factory func(cfg map[string]interface{}) (StreamingRecognizer, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register adds a driver factory under the given provider name. Drivers
+// call this from an init() function so selecting them is just a matter of
+// importing the driver package for its side effect.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// New builds the StreamingRecognizer registered for provider.
+func New(provider string, cfg map[string]interface{}) (StreamingRecognizer, error) {
+	registryMu.RLock()
+	factory, ok := registry[provider]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("asr: unknown provider %q", provider)
+	}
+	return factory(cfg)
+}
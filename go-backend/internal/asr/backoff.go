@@ -0,0 +1,32 @@
+package asr
+
+import "time"
+
+// backoff implements a capped exponential backoff used by drivers that
+// hold an outbound connection to a remote recognition service.
+type backoff struct {
+	base    time.Duration
+	max     time.Duration
+	current time.Duration
+}
+
+func newBackoff(base, max time.Duration) *backoff {
+	return &backoff{base: base, max: max, current: base}
+}
+
+// Next returns the delay to wait before the next reconnect attempt and
+// advances the internal state for the following call.
+func (b *backoff) Next() time.Duration {
+	d := b.current
+	b.current *= 2
+	if b.current > b.max {
+		b.current = b.max
+	}
+	return d
+}
+
+// Reset returns the backoff to its initial delay, called after a
+// successful (re)connect.
+func (b *backoff) Reset() {
+	b.current = b.base
+}
@@ -0,0 +1,28 @@
+package asr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDoublesUpToMax(t *testing.T) {
+	b := newBackoff(100*time.Millisecond, 1*time.Second)
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, 1 * time.Second, 1 * time.Second}
+	for i, w := range want {
+		if got := b.Next(); got != w {
+			t.Fatalf("Next() call %d = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestBackoffReset(t *testing.T) {
+	b := newBackoff(100*time.Millisecond, 1*time.Second)
+	b.Next()
+	b.Next()
+	b.Reset()
+
+	if got, want := b.Next(), 100*time.Millisecond; got != want {
+		t.Fatalf("Next() after Reset = %v, want %v", got, want)
+	}
+}
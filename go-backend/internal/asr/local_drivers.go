@@ -0,0 +1,32 @@
+package asr
+
+import (
+	"context"
+	"fmt"
+)
+
+// The sherpa, sensevoice and whisper drivers select an on-box model via
+// ASRConfig.Provider. They are registered here so the provider name is
+// already wired up end to end; the actual model integration is tracked
+// separately and Start currently returns a clear "not implemented" error
+// instead of silently behaving like remote-ws.
+
+func init() {
+	Register("sherpa", newUnimplementedRecognizer("sherpa"))
+	Register("sensevoice", newUnimplementedRecognizer("sensevoice"))
+	Register("whisper", newUnimplementedRecognizer("whisper"))
+}
+
+func newUnimplementedRecognizer(name string) Factory {
+	return func(map[string]interface{}) (StreamingRecognizer, error) {
+		return &unimplementedRecognizer{name: name}, nil
+	}
+}
+
+type unimplementedRecognizer struct{ name string }
+
+func (u *unimplementedRecognizer) Name() string { return u.name }
+
+func (u *unimplementedRecognizer) Start(ctx context.Context, params Params) (Session, error) {
+	return nil, fmt.Errorf("asr: driver %q is not implemented yet, use \"remote-ws\"", u.name)
+}
@@ -0,0 +1,66 @@
+package asr
+
+import (
+	"context"
+	"sync"
+)
+
+// Manager keeps exactly one active Session per client_uid so repeated
+// mic-audio-data frames from the same connection reuse the same
+// recognizer instance instead of spinning up a new one per chunk.
+type Manager struct {
+	recognizer StreamingRecognizer
+
+	mu       sync.Mutex
+	sessions map[string]Session
+}
+
+// NewManager wraps a StreamingRecognizer with per-client session tracking.
+func NewManager(recognizer StreamingRecognizer) *Manager {
+	return &Manager{
+		recognizer: recognizer,
+		sessions:   make(map[string]Session),
+	}
+}
+
+// Get returns the existing session for clientUID, starting a new one via
+// the underlying recognizer if none is open yet. created reports whether
+// this call started a brand new session, so callers know whether they
+// still need to start consuming Results().
+func (m *Manager) Get(ctx context.Context, clientUID string, params Params) (sess Session, created bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sess, ok := m.sessions[clientUID]; ok {
+		select {
+		case <-sess.Done():
+			// The driver force-ended this session on its own (e.g. the
+			// max-utterance guard) without us calling Close; forget it so
+			// we start a fresh one instead of handing back a dead stream.
+			delete(m.sessions, clientUID)
+		default:
+			return sess, false, nil
+		}
+	}
+
+	params.ClientUID = clientUID
+	sess, err = m.recognizer.Start(ctx, params)
+	if err != nil {
+		return nil, false, err
+	}
+	m.sessions[clientUID] = sess
+	return sess, true, nil
+}
+
+// Close closes and forgets the session belonging to clientUID, if any.
+// Called when a client disconnects or explicitly ends an utterance.
+func (m *Manager) Close(clientUID string) {
+	m.mu.Lock()
+	sess, ok := m.sessions[clientUID]
+	delete(m.sessions, clientUID)
+	m.mu.Unlock()
+
+	if ok {
+		sess.Close()
+	}
+}
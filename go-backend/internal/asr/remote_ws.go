@@ -0,0 +1,241 @@
+package asr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func init() {
+	Register("remote-ws", newRemoteWSRecognizer)
+}
+
+// remoteWSConfig is the driver-specific config pulled out of
+// ASRConfig for the "remote-ws" provider.
+type remoteWSConfig struct {
+	URL           string `mapstructure:"url"`
+	QueueSize     int    `mapstructure:"queue_size"`
+	ReconnectBase string `mapstructure:"reconnect_base"`
+	ReconnectMax  string `mapstructure:"reconnect_max"`
+}
+
+// remoteWSRecognizer opens an outbound WebSocket to a remote ASR service
+// per session and forwards binary audio frames to it, translating its
+// JSON partial/final events back into asr.Result values.
+type remoteWSRecognizer struct {
+	cfg remoteWSConfig
+}
+
+func newRemoteWSRecognizer(raw map[string]interface{}) (StreamingRecognizer, error) {
+	cfg := remoteWSConfig{QueueSize: 32, ReconnectBase: "250ms", ReconnectMax: "10s"}
+	if v, ok := raw["url"].(string); ok {
+		cfg.URL = v
+	}
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("asr: remote-ws driver requires a url")
+	}
+	if v, ok := raw["queue_size"].(int); ok && v > 0 {
+		cfg.QueueSize = v
+	}
+	return &remoteWSRecognizer{cfg: cfg}, nil
+}
+
+func (r *remoteWSRecognizer) Name() string { return "remote-ws" }
+
+func (r *remoteWSRecognizer) Start(ctx context.Context, params Params) (Session, error) {
+	base, _ := time.ParseDuration(r.cfg.ReconnectBase)
+	if base <= 0 {
+		base = 250 * time.Millisecond
+	}
+	max, _ := time.ParseDuration(r.cfg.ReconnectMax)
+	if max <= 0 {
+		max = 10 * time.Second
+	}
+
+	sessCtx, cancel := context.WithCancel(ctx)
+	sess := &remoteWSSession{
+		cfg:     r.cfg,
+		ctx:     sessCtx,
+		cancel:  cancel,
+		params:  params,
+		outbox:  make(chan []byte, r.cfg.QueueSize),
+		results: make(chan Result, 8),
+		done:    make(chan struct{}),
+		backoff: newBackoff(base, max),
+	}
+	go sess.run()
+	return sess, nil
+}
+
+// remoteWSEvent is the JSON shape sent by the remote recognizer.
+type remoteWSEvent struct {
+	Type string `json:"type"` // "partial", "final", "error"
+	Text string `json:"text"`
+	Err  string `json:"error"`
+}
+
+type remoteWSSession struct {
+	cfg    remoteWSConfig
+	ctx    context.Context
+	cancel context.CancelFunc
+	params Params
+
+	outbox  chan []byte
+	results chan Result
+	done    chan struct{}
+	backoff *backoff
+
+	mu        sync.Mutex
+	closed    bool
+	closeOnce sync.Once
+}
+
+func (s *remoteWSSession) Push(chunk []byte) error {
+	select {
+	case s.outbox <- chunk:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	default:
+		// Backpressure: the remote link is behind, so drop the oldest
+		// queued frame rather than blocking the WebSocket reader.
+		select {
+		case <-s.outbox:
+		default:
+		}
+		select {
+		case s.outbox <- chunk:
+		default:
+		}
+		return nil
+	}
+}
+
+func (s *remoteWSSession) Results() <-chan Result { return s.results }
+
+func (s *remoteWSSession) Done() <-chan struct{} { return s.done }
+
+func (s *remoteWSSession) Close() error {
+	s.closeOnce.Do(func() {
+		s.cancel()
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+		close(s.results)
+		close(s.done)
+	})
+	return nil
+}
+
+// run owns the outbound connection for the lifetime of the session,
+// reconnecting with exponential backoff and enforcing the max-utterance
+// guard.
+func (s *remoteWSSession) run() {
+	utteranceDeadline := time.NewTimer(MaxUtteranceDuration)
+	defer utteranceDeadline.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-utteranceDeadline.C:
+			s.emit(Result{IsFinal: true, Timestamp: time.Now()})
+			s.Close()
+			return
+		default:
+		}
+
+		if err := s.connectAndPump(utteranceDeadline); err != nil {
+			log.Printf("asr: remote-ws connection for %s failed: %v", s.params.ClientUID, err)
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(s.backoff.Next()):
+			}
+			continue
+		}
+
+		// connectAndPump only returns nil once the context is done (either
+		// the caller canceled it, or the max-utterance guard fired and
+		// closed the session itself).
+		return
+	}
+}
+
+// connectAndPump dials the remote recognizer and pumps audio/results over
+// it until the session's context is canceled, the connection drops, or
+// utteranceDeadline fires (the happy path never leaves this loop, so the
+// max-utterance guard has to be watched here too, not just in run's outer
+// select).
+func (s *remoteWSSession) connectAndPump(utteranceDeadline *time.Timer) error {
+	conn, _, err := websocket.DefaultDialer.Dial(s.cfg.URL, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	s.backoff.Reset()
+
+	go func() {
+		<-s.ctx.Done()
+		conn.Close()
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			_, payload, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var ev remoteWSEvent
+			if err := json.Unmarshal(payload, &ev); err != nil {
+				continue
+			}
+			switch ev.Type {
+			case "partial":
+				s.emit(Result{Text: ev.Text, Timestamp: time.Now()})
+			case "final":
+				s.emit(Result{Text: ev.Text, IsFinal: true, Timestamp: time.Now()})
+			case "error":
+				s.emit(Result{Err: fmt.Errorf("%s", ev.Err), Timestamp: time.Now()})
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case <-utteranceDeadline.C:
+			s.emit(Result{IsFinal: true, Timestamp: time.Now()})
+			s.Close()
+			return nil
+		case <-done:
+			return fmt.Errorf("remote ASR connection closed")
+		case chunk := <-s.outbox:
+			if err := conn.WriteMessage(websocket.BinaryMessage, chunk); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *remoteWSSession) emit(r Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.results <- r:
+	default:
+		// Results channel is backed up; drop rather than block the
+		// reader goroutine.
+	}
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T, ttl time.Duration) *Manager {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return &Manager{enabled: true, privateKey: key, publicKey: &key.PublicKey, ttl: ttl}
+}
+
+func TestIssueAndVerifyTokenRoundTrip(t *testing.T) {
+	m := newTestManager(t, time.Hour)
+
+	token, err := m.IssueToken("user-1")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	claims, err := m.VerifyToken(token)
+	if err != nil {
+		t.Fatalf("VerifyToken: %v", err)
+	}
+	if claims.UserID != "user-1" {
+		t.Fatalf("UserID = %q, want %q", claims.UserID, "user-1")
+	}
+}
+
+func TestVerifyTokenRejectsExpired(t *testing.T) {
+	m := newTestManager(t, -time.Minute)
+
+	token, err := m.IssueToken("user-1")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	if _, err := m.VerifyToken(token); err == nil {
+		t.Fatal("VerifyToken accepted an expired token")
+	}
+}
+
+func TestVerifyTokenRejectsWrongKey(t *testing.T) {
+	issuer := newTestManager(t, time.Hour)
+	verifier := newTestManager(t, time.Hour)
+
+	token, err := issuer.IssueToken("user-1")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	if _, err := verifier.VerifyToken(token); err == nil {
+		t.Fatal("VerifyToken accepted a token signed by a different key")
+	}
+}
+
+func TestDisabledManagerRejectsIssueAndVerify(t *testing.T) {
+	m := &Manager{enabled: false}
+
+	if _, err := m.IssueToken("user-1"); err == nil {
+		t.Fatal("IssueToken on a disabled Manager = nil error, want error")
+	}
+	if _, err := m.VerifyToken("anything"); err == nil {
+		t.Fatal("VerifyToken on a disabled Manager = nil error, want error")
+	}
+}
@@ -0,0 +1,49 @@
+package auth
+
+import "sync"
+
+// ConnectionLimiter enforces a per-user cap on concurrent WebSocket
+// connections so one tenant can't exhaust the server's connection pool.
+type ConnectionLimiter struct {
+	mu     sync.Mutex
+	max    int
+	counts map[string]int
+}
+
+// NewConnectionLimiter builds a limiter allowing up to max concurrent
+// connections per user. max <= 0 disables the cap.
+func NewConnectionLimiter(max int) *ConnectionLimiter {
+	return &ConnectionLimiter{max: max, counts: make(map[string]int)}
+}
+
+// Acquire reserves a connection slot for userID, returning false if the
+// user is already at its cap. Every successful Acquire must be paired
+// with a Release once the connection closes.
+func (l *ConnectionLimiter) Acquire(userID string) bool {
+	if l.max <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.counts[userID] >= l.max {
+		return false
+	}
+	l.counts[userID]++
+	return true
+}
+
+// Release frees the slot reserved by a prior successful Acquire.
+func (l *ConnectionLimiter) Release(userID string) {
+	if l.max <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.counts[userID] <= 0 {
+		return
+	}
+	l.counts[userID]--
+	if l.counts[userID] == 0 {
+		delete(l.counts, userID)
+	}
+}
@@ -15,6 +15,8 @@ type Config struct {
 	ASR    ASRConfig    `mapstructure:"asr"`
 	TTS    TTSConfig    `mapstructure:"tts"`
 	VAD    VADConfig    `mapstructure:"vad"`
+	Store  StoreConfig  `mapstructure:"store"`
+	Auth   AuthConfig   `mapstructure:"auth"`
 	System SystemConfig `mapstructure:"system"`
 }
 
@@ -23,25 +25,73 @@ type LLMConfig struct {
 	Model    string `mapstructure:"model"`
 	BaseURL  string `mapstructure:"base_url"`
 	APIKey   string `mapstructure:"api_key"`
+	// PersonaPath points at the default persona YAML file loaded at
+	// startup; per-session overrides arrive via the "set-persona"
+	// WebSocket message.
+	PersonaPath string `mapstructure:"persona_path"`
 }
 
 type ASRConfig struct {
 	Provider string `mapstructure:"provider"`
 	Model    string `mapstructure:"model"`
+	// Endpoint is the remote ASR service URL, used by drivers such as
+	// "remote-ws" that talk to an out-of-process recognizer.
+	Endpoint string `mapstructure:"endpoint"`
 }
 
 type TTSConfig struct {
 	Provider string `mapstructure:"provider"`
 	Voice    string `mapstructure:"voice"`
+	// Endpoint and APIKey configure the "http-api" driver.
+	Endpoint string `mapstructure:"endpoint"`
+	APIKey   string `mapstructure:"api_key"`
+	// ModelPath configures the "piper" driver's local voice model.
+	ModelPath string `mapstructure:"model_path"`
 }
 
 type VADConfig struct {
 	Provider string `mapstructure:"provider"`
+	// Aggressiveness ranges 0-3, higher values require stronger evidence
+	// of speech before triggering.
+	Aggressiveness int `mapstructure:"aggressiveness"`
+	// MinSpeechMs/MinSilenceMs/PrerollMs are all in milliseconds; see
+	// vad.Config for their exact semantics.
+	MinSpeechMs  int `mapstructure:"min_speech_ms"`
+	MinSilenceMs int `mapstructure:"min_silence_ms"`
+	PrerollMs    int `mapstructure:"preroll_ms"`
+}
+
+type StoreConfig struct {
+	// Path is the SQLite database file used to persist sessions and
+	// chat history.
+	Path string `mapstructure:"path"`
+}
+
+type AuthConfig struct {
+	// Enabled gates JWT enforcement; local dev can leave it false to
+	// bypass auth entirely without needing real RSA keys on disk.
+	Enabled bool `mapstructure:"enabled"`
+	// PrivateKeyPath/PublicKeyPath point at PEM-encoded RSA keys used to
+	// sign and verify tokens; only read when Enabled is true.
+	PrivateKeyPath string `mapstructure:"private_key_path"`
+	PublicKeyPath  string `mapstructure:"public_key_path"`
+	// TokenTTLMinutes controls how long an issued JWT stays valid.
+	TokenTTLMinutes int `mapstructure:"token_ttl_minutes"`
+	// MaxConnectionsPerUser caps concurrent WebSocket connections per
+	// authenticated user; 0 disables the cap.
+	MaxConnectionsPerUser int `mapstructure:"max_connections_per_user"`
 }
 
 type SystemConfig struct {
 	MaxConnections int `mapstructure:"max_connections"`
 	BufferSize     int `mapstructure:"buffer_size"`
+	// RateLimitPerSecond and RateLimitBurst configure the per-connection
+	// token-bucket limiter applied to inbound WebSocket messages.
+	RateLimitPerSecond float64 `mapstructure:"rate_limit_per_second"`
+	RateLimitBurst     int     `mapstructure:"rate_limit_burst"`
+	// ShutdownTimeoutSeconds bounds how long Server.Shutdown waits for
+	// in-flight connections and pipelines to drain before forcing exit.
+	ShutdownTimeoutSeconds int `mapstructure:"shutdown_timeout_seconds"`
 }
 
 func LoadConfig() *Config {
@@ -58,6 +108,20 @@ func LoadConfig() *Config {
 	viper.SetDefault("debug", false)
 	viper.SetDefault("system.max_connections", 1000)
 	viper.SetDefault("system.buffer_size", 1024)
+	viper.SetDefault("system.rate_limit_per_second", 20.0)
+	viper.SetDefault("system.rate_limit_burst", 40)
+	viper.SetDefault("system.shutdown_timeout_seconds", 10)
+	viper.SetDefault("vad.aggressiveness", 2)
+	viper.SetDefault("vad.min_speech_ms", 100)
+	viper.SetDefault("vad.min_silence_ms", 500)
+	viper.SetDefault("vad.preroll_ms", 300)
+	viper.SetDefault("store.path", "./data/sessions.db")
+	viper.SetDefault("llm.persona_path", "configs/personas/default.yaml")
+	viper.SetDefault("auth.enabled", false)
+	viper.SetDefault("auth.private_key_path", "configs/keys/jwt_private.pem")
+	viper.SetDefault("auth.public_key_path", "configs/keys/jwt_public.pem")
+	viper.SetDefault("auth.token_ttl_minutes", 60)
+	viper.SetDefault("auth.max_connections_per_user", 5)
 
 	// 尝试读取配置文件（如果存在）
 	if err := viper.ReadInConfig(); err != nil {
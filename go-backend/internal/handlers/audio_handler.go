@@ -2,22 +2,69 @@ package handlers
 
 import (
 	"bytes"
-	"encoding/json"
-	"io"
+	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go-open-llm-vtuber/internal/asr"
 	"go-open-llm-vtuber/internal/config"
+	"go-open-llm-vtuber/internal/llm"
+	"go-open-llm-vtuber/internal/tts"
 )
 
+// restASRSampleRate is the PCM sample rate assumed for one-shot audio
+// submitted to /api/audio/process, matching the default the WebSocket
+// VAD pipeline negotiates for mic-audio-data frames.
+const restASRSampleRate = 16000
+
+// restASRTimeout bounds how long ProcessAudio waits for a final result
+// from the recognizer before giving up on a single request.
+const restASRTimeout = 15 * time.Second
+
 type AudioHandler struct {
-	config *config.Config
+	config        *config.Config
+	ttsSynth      tts.Synthesizer
+	asrRecognizer asr.StreamingRecognizer
+	llmProvider   llm.Provider
 }
 
 func NewAudioHandler(config *config.Config) *AudioHandler {
+	synth, err := tts.New(config.TTS.Provider, map[string]interface{}{
+		"url":        config.TTS.Endpoint,
+		"api_key":    config.TTS.APIKey,
+		"model_path": config.TTS.ModelPath,
+	})
+	if err != nil {
+		log.Printf("tts: 初始化 %q 驱动失败，音频处理接口的语音合成将不可用: %v", config.TTS.Provider, err)
+	}
+
+	recognizer, err := asr.New(config.ASR.Provider, map[string]interface{}{
+		"url": config.ASR.Endpoint,
+	})
+	if err != nil {
+		log.Printf("asr: 初始化 %q 驱动失败，音频处理接口的语音识别将不可用: %v", config.ASR.Provider, err)
+	}
+
+	provider, err := llm.New(config.LLM.Provider, map[string]interface{}{
+		"base_url": config.LLM.BaseURL,
+		"api_key":  config.LLM.APIKey,
+	})
+	if err != nil {
+		log.Printf("llm: 初始化 %q 驱动失败，音频处理接口的对话生成将不可用: %v", config.LLM.Provider, err)
+	}
+
 	return &AudioHandler{
-		config: config,
+		config:        config,
+		ttsSynth:      synth,
+		asrRecognizer: recognizer,
+		llmProvider:   provider,
 	}
 }
 
@@ -76,40 +123,99 @@ func (h *AudioHandler) processAudioData(audioData []byte, format string) (*Audio
 	}, nil
 }
 
+// callASRService runs audioData through the configured asr.StreamingRecognizer
+// as a single one-shot utterance: push the whole clip, then wait for the
+// first final result (or restASRTimeout, whichever comes first).
 func (h *AudioHandler) callASRService(audioData []byte, format string) (string, error) {
-	// 这里实现调用ASR服务的逻辑
-	// 可以是调用本地模型或者远程API
-	
-	// 模拟ASR调用
 	log.Printf("调用ASR服务，格式: %s", format)
-	
-	// 实际实现中这里会调用具体的ASR模型
-	// 例如：Sherpa ONNX, SenseVoice, Whisper等
-	return "用户说的内容", nil
+
+	if h.asrRecognizer == nil {
+		return "", fmt.Errorf("asr: 未配置可用的语音识别驱动")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), restASRTimeout)
+	defer cancel()
+
+	sess, err := h.asrRecognizer.Start(ctx, asr.Params{
+		ClientUID:  uuid.NewString(),
+		SampleRate: restASRSampleRate,
+	})
+	if err != nil {
+		return "", fmt.Errorf("asr: 启动识别会话失败: %w", err)
+	}
+	defer sess.Close()
+
+	if err := sess.Push(audioData); err != nil {
+		return "", fmt.Errorf("asr: 推送音频数据失败: %w", err)
+	}
+
+	for {
+		select {
+		case result, ok := <-sess.Results():
+			if !ok {
+				return "", fmt.Errorf("asr: 识别会话在收到最终结果前已关闭")
+			}
+			if result.Err != nil {
+				return "", result.Err
+			}
+			if result.IsFinal {
+				return result.Text, nil
+			}
+		case <-ctx.Done():
+			return "", fmt.Errorf("asr: 等待识别结果超时: %w", ctx.Err())
+		}
+	}
 }
 
+// callLLMService runs a single non-streaming chat completion through the
+// configured llm.Provider, collecting the streamed tokens into one reply.
 func (h *AudioHandler) callLLMService(inputText string) (string, error) {
-	// 这里实现调用LLM服务的逻辑
-	// 可以是调用本地模型或者远程API
-	
-	// 模拟LLM调用
 	log.Printf("调用LLM服务，输入: %s", inputText)
-	
-	// 实际实现中这里会调用具体的LLM
-	// 例如：OpenAI, Ollama, Claude等
-	return "AI的回复内容", nil
+
+	if h.llmProvider == nil {
+		return "", fmt.Errorf("llm: 未配置可用的对话驱动")
+	}
+
+	tokens, err := h.llmProvider.Chat(context.Background(), []llm.Message{{Role: "user", Content: inputText}}, llm.Options{
+		Model: h.config.LLM.Model,
+	})
+	if err != nil {
+		return "", fmt.Errorf("llm: 调用对话服务失败: %w", err)
+	}
+
+	var reply strings.Builder
+	for token := range tokens {
+		reply.WriteString(token.Delta)
+	}
+	return reply.String(), nil
 }
 
 func (h *AudioHandler) callTTSService(text string) (string, error) {
-	// 这里实现调用TTS服务的逻辑
-	// 可以是调用本地模型或者远程API
-	
-	// 模拟TTS调用
 	log.Printf("调用TTS服务，文本: %s", text)
-	
-	// 实际实现中这里会调用具体的TTS模型
-	// 例如：Edge TTS, Piper TTS, ElevenLabs等
-	return "/audio/generated.mp3", nil
+
+	if h.ttsSynth == nil {
+		return "", fmt.Errorf("tts: 未配置可用的语音合成驱动")
+	}
+
+	chunks, err := h.ttsSynth.SynthesizeStream(context.Background(), text, h.config.TTS.Voice)
+	if err != nil {
+		return "", err
+	}
+
+	var audio bytes.Buffer
+	for chunk := range chunks {
+		audio.Write(chunk.Data)
+	}
+
+	if err := os.MkdirAll("./static/audio", 0o755); err != nil {
+		return "", fmt.Errorf("tts: 无法创建音频输出目录: %w", err)
+	}
+	filename := uuid.NewString() + ".mp3"
+	if err := os.WriteFile(filepath.Join("./static/audio", filename), audio.Bytes(), 0o644); err != nil {
+		return "", fmt.Errorf("tts: 无法写入音频文件: %w", err)
+	}
+
+	return "/static/audio/" + filename, nil
 }
 
 // 处理WebSocket音频数据流
@@ -127,4 +233,4 @@ func (h *AudioHandler) ProcessWebSocketAudio(audioData []float32) (string, error
 	}
 
 	return h.callLLMService(text)
-}
\ No newline at end of file
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket is a simple per-connection rate limiter for inbound
+// WebSocket messages: it allows up to burst messages at once, then
+// refills at ratePerSecond tokens/second.
+type tokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	max        float64
+	refillRate float64
+	last       time.Time
+}
+
+func newTokenBucket(ratePerSecond float64, burst int) *tokenBucket {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		tokens:     float64(burst),
+		max:        float64(burst),
+		refillRate: ratePerSecond,
+		last:       time.Now(),
+	}
+}
+
+// Allow reports whether a message may be processed right now, consuming
+// one token if so.
+func (b *tokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+
+	b.tokens += elapsed * b.refillRate
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketAllowsBurstThenDenies(t *testing.T) {
+	b := newTokenBucket(1, 3)
+
+	for i := 0; i < 3; i++ {
+		if !b.Allow() {
+			t.Fatalf("Allow() call %d = false, want true within burst", i)
+		}
+	}
+	if b.Allow() {
+		t.Fatal("Allow() after burst exhausted = true, want false")
+	}
+}
+
+func TestTokenBucketRefillsOverTime(t *testing.T) {
+	b := newTokenBucket(100, 1)
+
+	if !b.Allow() {
+		t.Fatal("Allow() first call = false, want true")
+	}
+	if b.Allow() {
+		t.Fatal("Allow() immediately after exhausting burst = true, want false")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("Allow() after refill window = false, want true")
+	}
+}
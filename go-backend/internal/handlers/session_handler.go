@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go-open-llm-vtuber/internal/auth"
+	"go-open-llm-vtuber/internal/store"
+)
+
+// SessionHandler exposes the chat Store as a REST API under /api/sessions.
+// Every call is scoped to the requester's user id, resolved from the JWT
+// that auth.Manager.RequireAuth attaches to the request context (empty
+// when auth is disabled).
+type SessionHandler struct {
+	store store.Store
+}
+
+func NewSessionHandler(store store.Store) *SessionHandler {
+	return &SessionHandler{store: store}
+}
+
+type createSessionRequest struct {
+	Name string `json:"name"`
+}
+
+func (h *SessionHandler) CreateSession(c *gin.Context) {
+	var req createSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.store.CreateSession(c.Request.Context(), auth.UserID(c), req.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, session)
+}
+
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.store.ListSessions(c.Request.Context(), auth.UserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (h *SessionHandler) GetSession(c *gin.Context) {
+	session, err := h.store.GetSession(c.Request.Context(), auth.UserID(c), c.Param("id"), 0)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, session)
+}
+
+func (h *SessionHandler) DeleteSession(c *gin.Context) {
+	if err := h.store.DeleteSession(c.Request.Context(), auth.UserID(c), c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+type renameSessionRequest struct {
+	Name string `json:"name"`
+}
+
+func (h *SessionHandler) RenameSession(c *gin.Context) {
+	var req renameSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.store.RenameSession(c.Request.Context(), auth.UserID(c), c.Param("id"), req.Name); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// ListMessages serves /api/sessions/:id/messages?since=<unix-seconds>,
+// returning only messages newer than since for pagination.
+func (h *SessionHandler) ListMessages(c *gin.Context) {
+	var since int64
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since parameter"})
+			return
+		}
+		since = parsed
+	}
+
+	session, err := h.store.GetSession(c.Request.Context(), auth.UserID(c), c.Param("id"), since)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, session.Messages)
+}
+
+func (h *SessionHandler) SearchMessages(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing q parameter"})
+		return
+	}
+
+	messages, err := h.store.SearchMessages(c.Request.Context(), auth.UserID(c), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, messages)
+}
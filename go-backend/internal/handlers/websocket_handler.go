@@ -1,14 +1,42 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
+	"encoding/base64"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"go-open-llm-vtuber/internal/asr"
+	"go-open-llm-vtuber/internal/auth"
 	"go-open-llm-vtuber/internal/config"
+	"go-open-llm-vtuber/internal/llm"
+	"go-open-llm-vtuber/internal/models"
+	"go-open-llm-vtuber/internal/store"
+	"go-open-llm-vtuber/internal/tts"
+	"go-open-llm-vtuber/internal/vad"
+)
+
+// historyReplayLimit caps how many past messages are streamed back to a
+// reconnecting client so refresh doesn't lose context.
+const historyReplayLimit = 50
+
+const (
+	// writeWait bounds how long a single WebSocket write (including pings
+	// and close frames) may block.
+	writeWait = 10 * time.Second
+	// pongWait is how long we tolerate silence from the client before
+	// considering the connection dead; it must exceed pingPeriod so the
+	// pong to each ping can arrive before the read deadline expires. A
+	// single missed pong is enough to let the deadline lapse.
+	pongWait = 60 * time.Second
+	// pingPeriod is how often the server proactively pings idle clients.
+	pingPeriod = (pongWait * 9) / 10
 )
 
 var upgrader = websocket.Upgrader{
@@ -17,13 +45,63 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// client tracks the per-connection state the handler needs beyond the
+// raw *websocket.Conn, such as its stable client_uid. sampleRate is not
+// set by the handler; each mic-audio-data frame's own sample_rate is
+// what reaches the ASR session.
+type client struct {
+	conn         *websocket.Conn
+	clientUID    string
+	sampleRate   int
+	ttsPipeline  *tts.Pipeline
+	vadDetector  vad.Detector
+	asrSpeaking  bool
+	sessionID    string
+	systemPrompt string
+
+	// userID is the tenant resolved from the connection's JWT at
+	// handshake time (empty when auth is disabled), used to scope every
+	// store query this connection makes.
+	userID string
+
+	// send is the per-connection outbound queue drained by writePump;
+	// all writes to conn go through it so a single writer goroutine owns
+	// the socket. It is closed exactly once, by unregister, to signal
+	// writePump to send a close frame and exit.
+	send chan Message
+	// limiter throttles inbound messages from this connection.
+	limiter *tokenBucket
+}
+
 type WebSocketHandler struct {
-	config      *config.Config
-	clients     map[*websocket.Conn]bool
-	broadcast   chan Message
-	register    chan *websocket.Conn
-	unregister  chan *websocket.Conn
-	mutex       sync.RWMutex
+	config     *config.Config
+	clients    map[*websocket.Conn]*client
+	broadcast  chan Message
+	register   chan *client
+	unregister chan *websocket.Conn
+	mutex      sync.RWMutex
+
+	// shuttingDown rejects new connections once Shutdown has started.
+	shuttingDown bool
+	// connWG tracks live HandleWebSocket goroutines so Shutdown can wait
+	// for them to drain (or give up once its context expires).
+	connWG sync.WaitGroup
+	// pipelineWG tracks in-flight LLM/TTS pipeline goroutines (the speak
+	// goroutine spawned per response) so Shutdown also waits for those to
+	// finish, not just the read loop that kicked them off.
+	pipelineWG sync.WaitGroup
+
+	asrManager *asr.Manager
+	ttsSynth   tts.Synthesizer
+	vadConfig  vad.Config
+	newVAD     func() (vad.Detector, error)
+	store      store.Store
+
+	llmProvider    llm.Provider
+	defaultPersona string
+
+	authManager *auth.Manager
+	connLimiter *auth.ConnectionLimiter
 }
 
 type Message struct {
@@ -31,13 +109,79 @@ type Message struct {
 	Data interface{} `json:"data"`
 }
 
-func NewWebSocketHandler(config *config.Config) *WebSocketHandler {
+// micAudioData is the payload of a "mic-audio-data" message: one PCM
+// chunk from the browser's mic, tagged with its sample rate and a
+// monotonically increasing sequence number for reordering/diagnostics.
+type micAudioData struct {
+	Audio      []byte `json:"audio"`
+	SampleRate int    `json:"sample_rate"`
+	Seq        int    `json:"seq"`
+}
+
+func NewWebSocketHandler(cfg *config.Config, sessionStore store.Store, authManager *auth.Manager) *WebSocketHandler {
+	recognizer, err := asr.New(cfg.ASR.Provider, map[string]interface{}{
+		"url": cfg.ASR.Endpoint,
+	})
+	if err != nil {
+		log.Printf("asr: 初始化 %q 驱动失败，音频输入将不可用: %v", cfg.ASR.Provider, err)
+	}
+
+	synth, err := tts.New(cfg.TTS.Provider, map[string]interface{}{
+		"url":        cfg.TTS.Endpoint,
+		"api_key":    cfg.TTS.APIKey,
+		"model_path": cfg.TTS.ModelPath,
+	})
+	if err != nil {
+		log.Printf("tts: 初始化 %q 驱动失败，语音合成将不可用: %v", cfg.TTS.Provider, err)
+	}
+
+	vadConfig := vad.Config{
+		Aggressiveness:  cfg.VAD.Aggressiveness,
+		MinSpeechMs:     cfg.VAD.MinSpeechMs,
+		MinSilenceMs:    cfg.VAD.MinSilenceMs,
+		PrerollMs:       cfg.VAD.PrerollMs,
+		SampleRate:      16000,
+		FrameDurationMs: 20,
+	}
+
+	provider, err := llm.New(cfg.LLM.Provider, map[string]interface{}{
+		"base_url": cfg.LLM.BaseURL,
+		"api_key":  cfg.LLM.APIKey,
+	})
+	if err != nil {
+		log.Printf("llm: 初始化 %q 驱动失败，对话将不可用: %v", cfg.LLM.Provider, err)
+	}
+
+	var defaultPersona string
+	if cfg.LLM.PersonaPath != "" {
+		if persona, err := llm.LoadPersona(cfg.LLM.PersonaPath); err != nil {
+			log.Printf("llm: 加载人设文件失败: %v", err)
+		} else {
+			defaultPersona = persona.SystemPrompt
+		}
+	}
+
 	handler := &WebSocketHandler{
-		config:    config,
-		clients:   make(map[*websocket.Conn]bool),
-		broadcast: make(chan Message),
-		register:  make(chan *websocket.Conn),
-		unregister: make(chan *websocket.Conn),
+		config:         cfg,
+		clients:        make(map[*websocket.Conn]*client),
+		broadcast:      make(chan Message),
+		register:       make(chan *client),
+		unregister:     make(chan *websocket.Conn),
+		ttsSynth:       synth,
+		vadConfig:      vadConfig,
+		store:          sessionStore,
+		llmProvider:    provider,
+		defaultPersona: defaultPersona,
+		authManager:    authManager,
+		connLimiter:    auth.NewConnectionLimiter(cfg.Auth.MaxConnectionsPerUser),
+	}
+	if cfg.VAD.Provider != "" {
+		handler.newVAD = func() (vad.Detector, error) {
+			return vad.New(cfg.VAD.Provider, vadConfig)
+		}
+	}
+	if recognizer != nil {
+		handler.asrManager = asr.NewManager(recognizer)
 	}
 
 	go handler.handleClients()
@@ -46,16 +190,72 @@ func NewWebSocketHandler(config *config.Config) *WebSocketHandler {
 }
 
 func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
+	if h.isShuttingDown() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "server is shutting down"})
+		return
+	}
+	if max := h.config.System.MaxConnections; max > 0 && h.connectionCount() >= max {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "too many connections"})
+		return
+	}
+
+	var userID string
+	if h.authManager.Enabled() {
+		claims, err := h.authManager.VerifyToken(c.Query("token"))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or missing token"})
+			return
+		}
+		userID = claims.UserID
+		if !h.connLimiter.Acquire(userID) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many connections for this user"})
+			return
+		}
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("WebSocket升级失败: %v", err)
+		if userID != "" {
+			h.connLimiter.Release(userID)
+		}
 		return
 	}
 	defer conn.Close()
+	if userID != "" {
+		defer h.connLimiter.Release(userID)
+	}
+
+	h.connWG.Add(1)
+	defer h.connWG.Done()
+
+	cl := &client{
+		conn:      conn,
+		clientUID: uuid.NewString(),
+		userID:    userID,
+		send:      make(chan Message, h.config.System.BufferSize),
+		limiter:   newTokenBucket(h.config.System.RateLimitPerSecond, h.config.System.RateLimitBurst),
+	}
+	if h.newVAD != nil {
+		detector, err := h.newVAD()
+		if err != nil {
+			log.Printf("vad: 初始化检测器失败，本次连接将不做端点检测: %v", err)
+		} else {
+			cl.vadDetector = detector
+		}
+	}
 
-	h.register <- conn
+	h.register <- cl
 	defer func() { h.unregister <- conn }()
 
+	go h.writePump(cl)
+
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
 	for {
 		var msg Message
 		err := conn.ReadJSON(&msg)
@@ -64,17 +264,109 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 			break
 		}
 
+		if !cl.limiter.Allow() {
+			h.sendToClient(conn, Message{Type: "rate-limited", Data: map[string]interface{}{"error": "too many messages, slow down"}})
+			continue
+		}
+
 		// 处理不同类型的消息
 		h.handleMessage(conn, msg)
 	}
 }
 
+// writePump is the sole goroutine that writes to cl.conn: it drains
+// cl.send and applies write deadlines, and pings the client periodically
+// so dead connections are detected even when idle. It returns (closing
+// the connection) on the first write error or once cl.send is closed by
+// unregister.
+func (h *WebSocketHandler) writePump(cl *client) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+	defer cl.conn.Close()
+
+	for {
+		select {
+		case msg, ok := <-cl.send:
+			cl.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				cl.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := cl.conn.WriteJSON(msg); err != nil {
+				log.Printf("发送消息到客户端失败: %v", err)
+				return
+			}
+		case <-ticker.C:
+			cl.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := cl.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// isShuttingDown reports whether Shutdown has been called and new
+// connections should be turned away.
+func (h *WebSocketHandler) isShuttingDown() bool {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return h.shuttingDown
+}
+
+// connectionCount returns the number of currently registered clients.
+func (h *WebSocketHandler) connectionCount() int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.clients)
+}
+
+// Shutdown asks every connected client to close, then waits (up to
+// ctx's deadline) for their HandleWebSocket goroutines and any in-flight
+// LLM/TTS pipelines to drain.
+func (h *WebSocketHandler) Shutdown(ctx context.Context) {
+	h.mutex.Lock()
+	h.shuttingDown = true
+	conns := make([]*websocket.Conn, 0, len(h.clients))
+	for conn := range h.clients {
+		conns = append(conns, conn)
+	}
+	h.mutex.Unlock()
+
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+	for _, conn := range conns {
+		conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(writeWait))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.connWG.Wait()
+		h.pipelineWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Printf("websocket: 关闭超时，强制退出，剩余连接数: %d", h.connectionCount())
+	}
+}
+
 func (h *WebSocketHandler) handleMessage(conn *websocket.Conn, msg Message) {
 	switch msg.Type {
 	case "text-input":
 		h.processTextInput(conn, msg.Data)
 	case "mic-audio-data":
-		h.processAudioData(msg.Data)
+		h.processAudioData(conn, msg.Data)
+	case "interrupt":
+		h.interrupt(conn)
+	case "set-persona":
+		h.setPersona(conn, msg.Data)
+	case "new-session":
+		h.newSession(conn, msg.Data)
+	case "switch-session":
+		h.switchSession(conn, msg.Data)
+	case "history-request":
+		h.historyRequest(conn)
 	case "fetch-configs":
 		h.sendConfigs(conn)
 	case "request-init-config":
@@ -87,42 +379,236 @@ func (h *WebSocketHandler) handleMessage(conn *websocket.Conn, msg Message) {
 func (h *WebSocketHandler) processTextInput(conn *websocket.Conn, data interface{}) {
 	// 处理文本输入，调用LLM服务
 	log.Printf("处理文本输入: %+v", data)
-	
-	// 这里将数据发送到LLM服务进行处理
-	// 模拟响应
-	response := Message{
-		Type: "llm-response",
-		Data: map[string]interface{}{
-			"text": "这是模拟的LLM响应",
-			"audioUrl": "",
-		},
+
+	text, ok := decodeTextInput(data)
+	if !ok {
+		return
+	}
+	h.appendMessage(conn, models.ChatMessage{Role: "user", Content: text})
+
+	if h.llmProvider == nil {
+		h.sendToClient(conn, Message{Type: "llm-error", Data: map[string]interface{}{"error": "no LLM provider configured"}})
+		return
+	}
+
+	systemPrompt := h.defaultPersona
+	if c := h.getClient(conn); c != nil && c.systemPrompt != "" {
+		systemPrompt = c.systemPrompt
+	}
+
+	tokens, err := h.llmProvider.Chat(context.Background(), []llm.Message{{Role: "user", Content: text}}, llm.Options{
+		Model:        h.config.LLM.Model,
+		SystemPrompt: systemPrompt,
+	})
+	if err != nil {
+		h.sendToClient(conn, Message{Type: "llm-error", Data: map[string]interface{}{"error": err.Error()}})
+		return
+	}
+
+	var ttsTokens chan string
+	if h.ttsSynth != nil {
+		ttsTokens = make(chan string, 8)
+		h.pipelineWG.Add(1)
+		go func() {
+			defer h.pipelineWG.Done()
+			h.speak(conn, ttsTokens)
+		}()
+	}
+
+	var reply strings.Builder
+	for token := range tokens {
+		if token.Delta != "" {
+			reply.WriteString(token.Delta)
+			h.sendToClient(conn, Message{Type: "llm-token", Data: map[string]interface{}{"delta": token.Delta}})
+			if ttsTokens != nil {
+				ttsTokens <- token.Delta
+			}
+		}
+	}
+	if ttsTokens != nil {
+		close(ttsTokens)
+	}
+
+	h.appendMessage(conn, models.ChatMessage{Role: "assistant", Content: reply.String()})
+}
+
+// setPersona overrides the system prompt used for conn's subsequent
+// LLM calls, e.g. to switch character mid-conversation.
+func (h *WebSocketHandler) setPersona(conn *websocket.Conn, data interface{}) {
+	prompt, _ := data.(map[string]interface{})["system_prompt"].(string)
+	if c := h.getClient(conn); c != nil {
+		c.systemPrompt = prompt
+	}
+}
+
+func decodeTextInput(data interface{}) (string, bool) {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	text, ok := m["text"].(string)
+	return text, ok
+}
+
+// appendMessage persists msg under conn's active session, if any store and
+// session are configured. Failures are logged rather than surfaced to the
+// client since chat should keep working even if history can't be saved.
+func (h *WebSocketHandler) appendMessage(conn *websocket.Conn, msg models.ChatMessage) {
+	if h.store == nil {
+		return
+	}
+	c := h.getClient(conn)
+	if c == nil || c.sessionID == "" {
+		return
+	}
+	if _, err := h.store.AppendMessage(context.Background(), c.userID, c.sessionID, msg); err != nil {
+		log.Printf("store: 保存消息失败: %v", err)
+	}
+}
+
+// newSession creates a fresh, empty session and makes it the active one
+// for conn.
+func (h *WebSocketHandler) newSession(conn *websocket.Conn, data interface{}) {
+	if h.store == nil {
+		return
+	}
+	name, _ := data.(map[string]interface{})["name"].(string)
+	if name == "" {
+		name = "New Chat"
+	}
+
+	c := h.getClient(conn)
+	var userID string
+	if c != nil {
+		userID = c.userID
+	}
+
+	session, err := h.store.CreateSession(context.Background(), userID, name)
+	if err != nil {
+		h.sendToClient(conn, Message{Type: "history-error", Data: map[string]interface{}{"error": err.Error()}})
+		return
+	}
+
+	if c != nil {
+		c.sessionID = session.ID
+	}
+	h.sendToClient(conn, Message{Type: "session-created", Data: session})
+}
+
+// switchSession makes an existing session the active one for conn and
+// replays its recent history.
+func (h *WebSocketHandler) switchSession(conn *websocket.Conn, data interface{}) {
+	if h.store == nil {
+		return
+	}
+	id, _ := data.(map[string]interface{})["session_id"].(string)
+	if id == "" {
+		return
+	}
+
+	if c := h.getClient(conn); c != nil {
+		c.sessionID = id
+	}
+	h.historyRequest(conn)
+}
+
+// historyRequest replays the last historyReplayLimit messages of conn's
+// active session, e.g. after a reconnect, so the client doesn't lose
+// context on refresh.
+func (h *WebSocketHandler) historyRequest(conn *websocket.Conn) {
+	if h.store == nil {
+		return
+	}
+	c := h.getClient(conn)
+	if c == nil || c.sessionID == "" {
+		return
+	}
+
+	session, err := h.store.GetSession(context.Background(), c.userID, c.sessionID, 0)
+	if err != nil {
+		h.sendToClient(conn, Message{Type: "history-error", Data: map[string]interface{}{"error": err.Error()}})
+		return
+	}
+
+	messages := session.Messages
+	if len(messages) > historyReplayLimit {
+		messages = messages[len(messages)-historyReplayLimit:]
+	}
+	h.sendToClient(conn, Message{Type: "history_replay", Data: map[string]interface{}{
+		"session_id": session.ID,
+		"messages":   messages,
+	}})
+}
+
+// speak runs tokens through a fresh TTS pipeline for conn's client,
+// replacing any pipeline still speaking, and streams the resulting
+// sentence/audio events back over the socket as they are produced.
+func (h *WebSocketHandler) speak(conn *websocket.Conn, tokens <-chan string) {
+	pipeline := tts.NewPipeline(h.ttsSynth, h.config.TTS.Voice)
+
+	h.mutex.Lock()
+	c, ok := h.clients[conn]
+	if ok {
+		if c.ttsPipeline != nil {
+			c.ttsPipeline.Interrupt()
+		}
+		c.ttsPipeline = pipeline
+	}
+	h.mutex.Unlock()
+	if !ok {
+		return
+	}
+
+	events := pipeline.Run(context.Background(), tokens)
+	for event := range events {
+		switch event.Type {
+		case tts.EventSentenceBegin:
+			h.sendToClient(conn, Message{Type: "tts-sentence-begin", Data: map[string]interface{}{"text": event.Text}})
+		case tts.EventSentenceEnd:
+			h.sendToClient(conn, Message{Type: "tts-sentence-end", Data: map[string]interface{}{"text": event.Text}})
+		case tts.EventAudioChunk:
+			h.sendToClient(conn, Message{Type: "tts-audio-chunk", Data: map[string]interface{}{
+				"audio": base64.StdEncoding.EncodeToString(event.Audio),
+			}})
+		}
+	}
+}
+
+// interrupt cancels whatever TTS pipeline is currently speaking to conn's
+// client, e.g. because the user barged in mid-sentence.
+func (h *WebSocketHandler) interrupt(conn *websocket.Conn) {
+	h.mutex.RLock()
+	c, ok := h.clients[conn]
+	h.mutex.RUnlock()
+	if ok && c.ttsPipeline != nil {
+		c.ttsPipeline.Interrupt()
 	}
-	
-	h.sendToClient(conn, response)
 }
 
 // 发送初始化配置
 func (h *WebSocketHandler) sendInitConfig(conn *websocket.Conn) {
+	clientUID := h.clientUID(conn)
+
 	// 模拟发送初始化配置，包括模型信息
 	response := Message{
 		Type: "set-model-and-conf",
 		Data: map[string]interface{}{
 			"model_info": map[string]interface{}{
-				"name": "mao_pro",
-				"url": "/live2d-models/mao_pro/runtime/mao_pro.model3.json",
-				"kScale": 0.5,
-				"initialXshift": 0,
-				"initialYshift": 0,
-				"kXOffset": 1150,
+				"name":                "mao_pro",
+				"url":                 "/live2d-models/mao_pro/runtime/mao_pro.model3.json",
+				"kScale":              0.5,
+				"initialXshift":       0,
+				"initialYshift":       0,
+				"kXOffset":            1150,
 				"idleMotionGroupName": "Idle",
 				"emotionMap": map[string]int{
-					"neutral": 0,
-					"anger": 2,
-					"disgust": 2,
-					"fear": 1,
-					"joy": 3,
-					"smirk": 3,
-					"sadness": 1,
+					"neutral":  0,
+					"anger":    2,
+					"disgust":  2,
+					"fear":     1,
+					"joy":      3,
+					"smirk":    3,
+					"sadness":  1,
 					"surprise": 3,
 				},
 				"tapMotions": map[string]interface{}{
@@ -134,23 +620,156 @@ func (h *WebSocketHandler) sendInitConfig(conn *websocket.Conn) {
 					},
 				},
 			},
-			"conf_name": "default",
-			"conf_uid": "default-uid",
-			"client_uid": "client-uid-placeholder",
+			"conf_name":  "default",
+			"conf_uid":   "default-uid",
+			"client_uid": clientUID,
+			"user_id":    h.userID(conn),
 		},
 	}
-	
+
 	h.sendToClient(conn, response)
 }
 
+// clientUID returns the stable id assigned to conn at registration time.
+func (h *WebSocketHandler) clientUID(conn *websocket.Conn) string {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if c, ok := h.clients[conn]; ok {
+		return c.clientUID
+	}
+	return ""
+}
 
+// userID returns the tenant resolved from conn's JWT at handshake time,
+// or "" when auth is disabled.
+func (h *WebSocketHandler) userID(conn *websocket.Conn) string {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if c, ok := h.clients[conn]; ok {
+		return c.userID
+	}
+	return ""
+}
 
-func (h *WebSocketHandler) processAudioData(data interface{}) {
-	// 处理音频数据，调用ASR服务
-	log.Printf("处理音频数据")
-	
-	// 这里将音频数据发送到ASR服务进行识别
-	// 识别结果再发送到LLM服务
+// getClient returns the client record registered for conn, if any.
+func (h *WebSocketHandler) getClient(conn *websocket.Conn) *client {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return h.clients[conn]
+}
+
+// processAudioData feeds one mic-audio-data frame through VAD endpointing
+// (when configured) and forwards it into the per-client ASR session for
+// as long as the detector considers the user to be speaking, relaying
+// vad-speech-start/end and asr-partial/final/error events back to the
+// same connection as they arrive.
+func (h *WebSocketHandler) processAudioData(conn *websocket.Conn, data interface{}) {
+	if h.asrManager == nil {
+		return
+	}
+
+	payload, ok := decodeMicAudioData(data)
+	if !ok {
+		log.Printf("无法解析mic-audio-data消息: %+v", data)
+		return
+	}
+
+	c := h.getClient(conn)
+	if c == nil {
+		return
+	}
+
+	var preroll []byte
+	if c.vadDetector != nil {
+		events, err := c.vadDetector.Process(payload.Audio)
+		if err != nil {
+			log.Printf("vad: 处理音频帧失败: %v", err)
+		}
+		for _, event := range events {
+			switch event.Type {
+			case vad.EventSpeechStart:
+				c.asrSpeaking = true
+				preroll = event.Preroll
+				h.sendToClient(conn, Message{Type: "vad-speech-start", Data: map[string]interface{}{}})
+			case vad.EventSpeechEnd:
+				c.asrSpeaking = false
+				h.sendToClient(conn, Message{Type: "vad-speech-end", Data: map[string]interface{}{}})
+				h.asrManager.Close(c.clientUID)
+			}
+		}
+		if !c.asrSpeaking {
+			return
+		}
+	}
+
+	sess, created, err := h.asrManager.Get(context.Background(), c.clientUID, asr.Params{
+		SampleRate: payload.SampleRate,
+	})
+	if err != nil {
+		h.sendToClient(conn, Message{Type: "asr-error", Data: map[string]interface{}{"error": err.Error()}})
+		return
+	}
+
+	if created {
+		go h.pumpASRResults(conn, c.clientUID, sess)
+		if len(preroll) > 0 {
+			sess.Push(preroll)
+		}
+	}
+
+	if err := sess.Push(payload.Audio); err != nil {
+		h.sendToClient(conn, Message{Type: "asr-error", Data: map[string]interface{}{"error": err.Error()}})
+	}
+}
+
+func (h *WebSocketHandler) pumpASRResults(conn *websocket.Conn, clientUID string, sess asr.Session) {
+	for result := range sess.Results() {
+		if result.Err != nil {
+			h.sendToClient(conn, Message{Type: "asr-error", Data: map[string]interface{}{"error": result.Err.Error()}})
+			continue
+		}
+
+		msgType := "asr-partial"
+		if result.IsFinal {
+			msgType = "asr-final"
+		}
+		h.sendToClient(conn, Message{Type: msgType, Data: map[string]interface{}{"text": result.Text}})
+
+		if result.IsFinal {
+			h.sendToClient(conn, Message{Type: "asr-endpoint", Data: map[string]interface{}{"client_uid": clientUID}})
+		}
+	}
+}
+
+func decodeMicAudioData(data interface{}) (micAudioData, bool) {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return micAudioData{}, false
+	}
+
+	var out micAudioData
+	if sr, ok := m["sample_rate"].(float64); ok {
+		out.SampleRate = int(sr)
+	}
+	if seq, ok := m["seq"].(float64); ok {
+		out.Seq = int(seq)
+	}
+	switch audio := m["audio"].(type) {
+	case []byte:
+		out.Audio = audio
+	case string:
+		// The browser JSON-encodes raw PCM as base64, mirroring how we
+		// encode outgoing tts-audio-chunk frames; JSON itself can't carry
+		// binary, so this is never a literal byte string.
+		decoded, err := base64.StdEncoding.DecodeString(audio)
+		if err != nil {
+			return micAudioData{}, false
+		}
+		out.Audio = decoded
+	default:
+		return micAudioData{}, false
+	}
+	return out, true
 }
 
 func (h *WebSocketHandler) sendConfigs(conn *websocket.Conn) {
@@ -159,55 +778,80 @@ func (h *WebSocketHandler) sendConfigs(conn *websocket.Conn) {
 		"asr": h.config.ASR,
 		"tts": h.config.TTS,
 	}
-	
+
 	response := Message{
 		Type: "configs",
 		Data: configs,
 	}
-	
+
 	h.sendToClient(conn, response)
 }
 
+// sendToClient enqueues msg for conn's writer goroutine. If the queue is
+// full the oldest pending message is dropped to make room, since a
+// slow/stalled client shouldn't be able to block the handler or grow the
+// queue unbounded.
 func (h *WebSocketHandler) sendToClient(conn *websocket.Conn, msg Message) {
-	h.mutex.RLock()
-	defer h.mutex.RUnlock()
-	
-	err := conn.WriteJSON(msg)
-	if err != nil {
-		log.Printf("发送消息到客户端失败: %v", err)
-		conn.Close()
+	c := h.getClient(conn)
+	if c == nil {
+		return
+	}
+	h.enqueue(c, msg)
+}
+
+func (h *WebSocketHandler) enqueue(c *client, msg Message) {
+	select {
+	case c.send <- msg:
+		return
+	default:
+	}
+	select {
+	case <-c.send:
+	default:
+	}
+	select {
+	case c.send <- msg:
+	default:
 	}
 }
 
 func (h *WebSocketHandler) handleClients() {
 	for {
 		select {
-		case conn := <-h.register:
+		case c := <-h.register:
 			h.mutex.Lock()
-			h.clients[conn] = true
+			h.clients[c.conn] = c
+			count := len(h.clients)
 			h.mutex.Unlock()
-			log.Printf("新客户端连接，当前连接数: %d", len(h.clients))
-			
+			log.Printf("新客户端连接，当前连接数: %d", count)
+
 		case conn := <-h.unregister:
 			h.mutex.Lock()
-			if _, ok := h.clients[conn]; ok {
+			c, ok := h.clients[conn]
+			if ok {
 				delete(h.clients, conn)
-				conn.Close()
 			}
+			count := len(h.clients)
 			h.mutex.Unlock()
-			log.Printf("客户端断开连接，当前连接数: %d", len(h.clients))
-			
+			if ok {
+				close(c.send)
+				if h.asrManager != nil {
+					h.asrManager.Close(c.clientUID)
+				}
+				if c.vadDetector != nil {
+					if err := c.vadDetector.Close(); err != nil {
+						log.Printf("vad: 释放检测器失败: %v", err)
+					}
+				}
+			}
+			log.Printf("客户端断开连接，当前连接数: %d", count)
+
 		case message := <-h.broadcast:
 			h.mutex.RLock()
-			for conn := range h.clients {
-				err := conn.WriteJSON(message)
-				if err != nil {
-					log.Printf("广播消息失败: %v", err)
-					delete(h.clients, conn)
-					conn.Close()
-				}
+			for _, c := range h.clients {
+				h.enqueue(c, message)
 			}
 			h.mutex.RUnlock()
 		}
 	}
-}
\ No newline at end of file
+}
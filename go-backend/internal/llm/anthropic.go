@@ -0,0 +1,144 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func init() {
+	Register("anthropic", newAnthropicProvider)
+}
+
+// anthropicConfig configures the "anthropic" driver, which talks to the
+// Anthropic Messages API.
+type anthropicConfig struct {
+	BaseURL string `mapstructure:"base_url"`
+	APIKey  string `mapstructure:"api_key"`
+}
+
+type anthropicProvider struct {
+	cfg    anthropicConfig
+	client *http.Client
+}
+
+func newAnthropicProvider(raw map[string]interface{}) (Provider, error) {
+	cfg := anthropicConfig{BaseURL: "https://api.anthropic.com"}
+	if v, ok := raw["base_url"].(string); ok && v != "" {
+		cfg.BaseURL = v
+	}
+	if v, ok := raw["api_key"].(string); ok {
+		cfg.APIKey = v
+	}
+	return &anthropicProvider{cfg: cfg, client: &http.Client{Timeout: 2 * time.Minute}}, nil
+}
+
+func (p *anthropicProvider) Name() string { return "anthropic" }
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	MaxTokens int                `json:"max_tokens"`
+	Stream    bool               `json:"stream"`
+}
+
+// anthropicStreamEvent covers the fields we need out of the handful of
+// event types the Messages streaming API emits (message_start,
+// content_block_delta, message_delta, message_stop, ...).
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"delta"`
+}
+
+func (p *anthropicProvider) Chat(ctx context.Context, messages []Message, opts Options) (<-chan Token, error) {
+	reqMessages := make([]anthropicMessage, 0, len(messages))
+	for _, m := range messages {
+		reqMessages = append(reqMessages, anthropicMessage{Role: m.Role, Content: m.Content})
+	}
+
+	maxTokens := opts.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 1024
+	}
+
+	body, err := json.Marshal(anthropicRequest{
+		Model:     opts.Model,
+		System:    opts.SystemPrompt,
+		Messages:  reqMessages,
+		MaxTokens: maxTokens,
+		Stream:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.cfg.APIKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("llm: anthropic returned status %d", resp.StatusCode)
+	}
+
+	out := make(chan Token, 8)
+	go func() {
+		defer resp.Body.Close()
+		defer close(out)
+
+		sse := newSSEReader(bufio.NewScanner(resp.Body))
+		for {
+			data, ok := sse.Next()
+			if !ok {
+				return
+			}
+
+			var event anthropicStreamEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				continue
+			}
+
+			switch event.Type {
+			case "content_block_delta":
+				select {
+				case out <- Token{Delta: event.Delta.Text}:
+				case <-ctx.Done():
+					return
+				}
+			case "message_delta":
+				if event.Delta.StopReason != "" {
+					select {
+					case out <- Token{FinishReason: event.Delta.StopReason}:
+					case <-ctx.Done():
+					}
+					return
+				}
+			case "message_stop":
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
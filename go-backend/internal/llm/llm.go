@@ -0,0 +1,75 @@
+// Package llm provides a pluggable chat-completion provider registry with
+// streaming token output.
+package llm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Message is one turn of chat history sent to a Provider.
+type Message struct {
+	Role    string // "system", "user", "assistant"
+	Content string
+}
+
+// ToolCall is a tool invocation requested by the model mid-stream.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string // raw JSON arguments
+}
+
+// Token is one increment of a streamed chat completion.
+type Token struct {
+	Delta        string
+	FinishReason string
+	ToolCalls    []ToolCall
+}
+
+// Options configures a single Chat call.
+type Options struct {
+	Model        string
+	SystemPrompt string
+	Temperature  float64
+	MaxTokens    int
+}
+
+// Provider is implemented by every LLM driver.
+type Provider interface {
+	// Chat starts a streamed chat completion and returns a channel of
+	// Tokens. The channel is closed once the completion finishes (a
+	// Token with a non-empty FinishReason precedes the close) or ctx is
+	// canceled.
+	Chat(ctx context.Context, messages []Message, opts Options) (<-chan Token, error)
+	// Name returns the driver name as used in LLMConfig.Provider.
+	Name() string
+}
+
+// Factory builds a Provider from the driver-specific config map pulled
+// out of LLMConfig.
+type Factory func(cfg map[string]interface{}) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register adds a driver factory under the given provider name.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// New builds the Provider registered for provider.
+func New(provider string, cfg map[string]interface{}) (Provider, error) {
+	registryMu.RLock()
+	factory, ok := registry[provider]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("llm: unknown provider %q", provider)
+	}
+	return factory(cfg)
+}
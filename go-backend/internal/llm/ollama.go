@@ -0,0 +1,120 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func init() {
+	Register("ollama", newOllamaProvider)
+}
+
+// ollamaConfig configures the "ollama" driver, which talks to Ollama's
+// native /api/chat endpoint (newline-delimited JSON, not SSE).
+type ollamaConfig struct {
+	BaseURL string `mapstructure:"base_url"`
+}
+
+type ollamaProvider struct {
+	cfg    ollamaConfig
+	client *http.Client
+}
+
+func newOllamaProvider(raw map[string]interface{}) (Provider, error) {
+	cfg := ollamaConfig{BaseURL: "http://localhost:11434"}
+	if v, ok := raw["base_url"].(string); ok && v != "" {
+		cfg.BaseURL = v
+	}
+	return &ollamaProvider{cfg: cfg, client: &http.Client{Timeout: 2 * time.Minute}}, nil
+}
+
+func (p *ollamaProvider) Name() string { return "ollama" }
+
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+}
+
+type ollamaStreamLine struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done bool `json:"done"`
+}
+
+func (p *ollamaProvider) Chat(ctx context.Context, messages []Message, opts Options) (<-chan Token, error) {
+	reqMessages := make([]ollamaMessage, 0, len(messages)+1)
+	if opts.SystemPrompt != "" {
+		reqMessages = append(reqMessages, ollamaMessage{Role: "system", Content: opts.SystemPrompt})
+	}
+	for _, m := range messages {
+		reqMessages = append(reqMessages, ollamaMessage{Role: m.Role, Content: m.Content})
+	}
+
+	body, err := json.Marshal(ollamaRequest{Model: opts.Model, Messages: reqMessages, Stream: true})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("llm: ollama returned status %d", resp.StatusCode)
+	}
+
+	out := make(chan Token, 8)
+	go func() {
+		defer resp.Body.Close()
+		defer close(out)
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+
+			var chunk ollamaStreamLine
+			if err := json.Unmarshal(line, &chunk); err != nil {
+				continue
+			}
+
+			token := Token{Delta: chunk.Message.Content}
+			if chunk.Done {
+				token.FinishReason = "stop"
+			}
+
+			select {
+			case out <- token:
+			case <-ctx.Done():
+				return
+			}
+			if chunk.Done {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,148 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func init() {
+	Register("openai", newOpenAIProvider)
+}
+
+// openaiConfig configures the "openai" driver, which speaks the
+// OpenAI chat-completions wire format used by OpenAI itself as well as
+// Groq, DeepSeek and Together.
+type openaiConfig struct {
+	BaseURL string `mapstructure:"base_url"`
+	APIKey  string `mapstructure:"api_key"`
+}
+
+type openaiProvider struct {
+	cfg    openaiConfig
+	client *http.Client
+}
+
+func newOpenAIProvider(raw map[string]interface{}) (Provider, error) {
+	cfg := openaiConfig{BaseURL: "https://api.openai.com/v1"}
+	if v, ok := raw["base_url"].(string); ok && v != "" {
+		cfg.BaseURL = v
+	}
+	if v, ok := raw["api_key"].(string); ok {
+		cfg.APIKey = v
+	}
+	return &openaiProvider{cfg: cfg, client: &http.Client{Timeout: 2 * time.Minute}}, nil
+}
+
+func (p *openaiProvider) Name() string { return "openai" }
+
+type openaiRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openaiMessage `json:"messages"`
+	Stream      bool            `json:"stream"`
+	Temperature float64         `json:"temperature,omitempty"`
+	MaxTokens   int             `json:"max_tokens,omitempty"`
+}
+
+type openaiMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openaiStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				ID       string `json:"id"`
+				Function struct {
+					Name      string `json:"name"`
+					Arguments string `json:"arguments"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+func (p *openaiProvider) Chat(ctx context.Context, messages []Message, opts Options) (<-chan Token, error) {
+	reqMessages := make([]openaiMessage, 0, len(messages)+1)
+	if opts.SystemPrompt != "" {
+		reqMessages = append(reqMessages, openaiMessage{Role: "system", Content: opts.SystemPrompt})
+	}
+	for _, m := range messages {
+		reqMessages = append(reqMessages, openaiMessage{Role: m.Role, Content: m.Content})
+	}
+
+	body, err := json.Marshal(openaiRequest{
+		Model:       opts.Model,
+		Messages:    reqMessages,
+		Stream:      true,
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.cfg.APIKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("llm: openai returned status %d", resp.StatusCode)
+	}
+
+	out := make(chan Token, 8)
+	go func() {
+		defer resp.Body.Close()
+		defer close(out)
+
+		sse := newSSEReader(bufio.NewScanner(resp.Body))
+		for {
+			data, ok := sse.Next()
+			if !ok {
+				return
+			}
+			if data == "[DONE]" {
+				return
+			}
+
+			var chunk openaiStreamChunk
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				continue
+			}
+			if len(chunk.Choices) == 0 {
+				continue
+			}
+			choice := chunk.Choices[0]
+
+			var toolCalls []ToolCall
+			for _, tc := range choice.Delta.ToolCalls {
+				toolCalls = append(toolCalls, ToolCall{ID: tc.ID, Name: tc.Function.Name, Arguments: tc.Function.Arguments})
+			}
+
+			select {
+			case out <- Token{Delta: choice.Delta.Content, FinishReason: choice.FinishReason, ToolCalls: toolCalls}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOpenAIProviderChatStreamsTokens(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+
+		chunks := []string{
+			`{"choices":[{"delta":{"content":"Hel"}}]}`,
+			`{"choices":[{"delta":{"content":"lo"}}]}`,
+			`{"choices":[{"delta":{},"finish_reason":"stop"}]}`,
+		}
+		for _, c := range chunks {
+			fmt.Fprintf(w, "data: %s\n\n", c)
+			flusher.Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		flusher.Flush()
+	}))
+	defer srv.Close()
+
+	provider, err := newOpenAIProvider(map[string]interface{}{"base_url": srv.URL})
+	if err != nil {
+		t.Fatalf("newOpenAIProvider: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tokens, err := provider.Chat(ctx, []Message{{Role: "user", Content: "hi"}}, Options{Model: "gpt-test"})
+	if err != nil {
+		t.Fatalf("Chat: %v", err)
+	}
+
+	var got string
+	var finishReason string
+	for tok := range tokens {
+		got += tok.Delta
+		if tok.FinishReason != "" {
+			finishReason = tok.FinishReason
+		}
+	}
+
+	if got != "Hello" {
+		t.Fatalf("streamed delta = %q, want %q", got, "Hello")
+	}
+	if finishReason != "stop" {
+		t.Fatalf("finish reason = %q, want %q", finishReason, "stop")
+	}
+}
+
+func TestOpenAIProviderChatPropagatesHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	provider, err := newOpenAIProvider(map[string]interface{}{"base_url": srv.URL})
+	if err != nil {
+		t.Fatalf("newOpenAIProvider: %v", err)
+	}
+
+	if _, err := provider.Chat(context.Background(), nil, Options{}); err == nil {
+		t.Fatal("Chat against a 401 response = nil error, want non-nil")
+	}
+}
@@ -0,0 +1,28 @@
+package llm
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Persona is a system-prompt preset loaded from configs/personas/*.yaml.
+type Persona struct {
+	Name         string `yaml:"name"`
+	SystemPrompt string `yaml:"system_prompt"`
+}
+
+// LoadPersona reads and parses a single persona file.
+func LoadPersona(path string) (*Persona, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("llm: failed to read persona %s: %w", path, err)
+	}
+
+	var persona Persona
+	if err := yaml.Unmarshal(data, &persona); err != nil {
+		return nil, fmt.Errorf("llm: failed to parse persona %s: %w", path, err)
+	}
+	return &persona, nil
+}
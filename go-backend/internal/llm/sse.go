@@ -0,0 +1,31 @@
+package llm
+
+import (
+	"bufio"
+	"strings"
+)
+
+// sseReader iterates the "data: ..." lines of a Server-Sent Events
+// stream, skipping blank lines, comments and any other SSE fields the
+// chat APIs used here don't need.
+type sseReader struct {
+	scanner *bufio.Scanner
+}
+
+func newSSEReader(scanner *bufio.Scanner) *sseReader {
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &sseReader{scanner: scanner}
+}
+
+// Next returns the payload of the next "data:" line, or ok=false once the
+// stream ends.
+func (r *sseReader) Next() (data string, ok bool) {
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		return strings.TrimSpace(strings.TrimPrefix(line, "data:")), true
+	}
+	return "", false
+}
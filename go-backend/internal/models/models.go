@@ -59,8 +59,18 @@ type ChatMessage struct {
 // Session represents a chat session
 type Session struct {
 	ID        string        `json:"id"`
+	UserID    string        `json:"user_id"`
 	Name      string        `json:"name"`
 	Messages  []ChatMessage `json:"messages"`
 	CreatedAt int64         `json:"created_at"`
 	UpdatedAt int64         `json:"updated_at"`
+}
+
+// User represents an authenticated account; its id is the tenant that
+// store.Store scopes sessions and messages by.
+type User struct {
+	ID           string `json:"id"`
+	Username     string `json:"username"`
+	PasswordHash string `json:"-"`
+	CreatedAt    int64  `json:"created_at"`
 }
\ No newline at end of file
@@ -1,28 +1,59 @@
 package server
 
 import (
+	"context"
+	"log"
+
+	"go-open-llm-vtuber/internal/auth"
 	"go-open-llm-vtuber/internal/config"
 	"go-open-llm-vtuber/internal/handlers"
+	"go-open-llm-vtuber/internal/store"
 
-	"github.com/gin-gonic/gin"
 	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
 )
 
 type Server struct {
-	config        *config.Config
-	wsHandler     *handlers.WebSocketHandler
-	audioHandler  *handlers.AudioHandler
+	config         *config.Config
+	wsHandler      *handlers.WebSocketHandler
+	audioHandler   *handlers.AudioHandler
+	sessionHandler *handlers.SessionHandler
+	authHandler    *handlers.AuthHandler
+	authManager    *auth.Manager
+	store          store.Store
 }
 
 func NewServer(config *config.Config) *Server {
-	wsHandler := handlers.NewWebSocketHandler(config)
+	sessionStore, err := store.NewSQLiteStore(config.Store.Path)
+	if err != nil {
+		// Sessions can't be persisted, but the rest of the server (audio
+		// pipeline, live config) still works, so keep booting.
+		log.Printf("store: 打开会话数据库失败，会话历史将不可用: %v", err)
+	}
+
+	authManager, err := auth.NewManager(config.Auth)
+	if err != nil {
+		// Without a usable key pair, fail safe to bypass mode rather than
+		// leave the server unable to start.
+		log.Printf("auth: 初始化失败，认证将被禁用: %v", err)
+		authManager = &auth.Manager{}
+	}
+
+	wsHandler := handlers.NewWebSocketHandler(config, sessionStore, authManager)
 	audioHandler := handlers.NewAudioHandler(config)
-	
-	return &Server{
+
+	s := &Server{
 		config:       config,
 		wsHandler:    wsHandler,
 		audioHandler: audioHandler,
+		authManager:  authManager,
+		store:        sessionStore,
+	}
+	if sessionStore != nil {
+		s.sessionHandler = handlers.NewSessionHandler(sessionStore)
+		s.authHandler = handlers.NewAuthHandler(authManager, sessionStore)
 	}
+	return s
 }
 
 func (s *Server) SetupRoutes(engine *gin.Engine) {
@@ -35,24 +66,61 @@ func (s *Server) SetupRoutes(engine *gin.Engine) {
 
 	// 静态文件服务
 	engine.Static("/static", "./static")
-	
+
 	// API路由
 	api := engine.Group("/api")
 	{
 		api.GET("/health", func(c *gin.Context) {
 			c.JSON(200, gin.H{"status": "ok"})
 		})
-		
+
 		// WebSocket路由
 		ws := api.Group("/ws")
 		{
 			ws.GET("/client", s.wsHandler.HandleWebSocket)
 		}
-		
+
 		// 音频处理路由
 		audio := api.Group("/audio")
 		{
 			audio.POST("/process", s.audioHandler.ProcessAudio)
 		}
+
+		// 认证路由
+		if s.authHandler != nil {
+			authGroup := api.Group("/auth")
+			{
+				authGroup.POST("/register", s.authHandler.Register)
+				authGroup.POST("/login", s.authHandler.Login)
+			}
+		}
+
+		// 会话历史路由
+		if s.sessionHandler != nil {
+			sessions := api.Group("/sessions")
+			sessions.Use(s.authManager.RequireAuth())
+			{
+				sessions.POST("", s.sessionHandler.CreateSession)
+				sessions.GET("", s.sessionHandler.ListSessions)
+				sessions.GET("/search", s.sessionHandler.SearchMessages)
+				sessions.GET("/:id", s.sessionHandler.GetSession)
+				sessions.PUT("/:id", s.sessionHandler.RenameSession)
+				sessions.DELETE("/:id", s.sessionHandler.DeleteSession)
+				sessions.GET("/:id/messages", s.sessionHandler.ListMessages)
+			}
+		}
 	}
-}
\ No newline at end of file
+}
+
+// Shutdown drains the WebSocket hub and closes the session store. It is
+// meant to be called with a bounded context after the HTTP listener has
+// stopped accepting new requests, so in-flight work gets a chance to
+// finish before the process exits.
+func (s *Server) Shutdown(ctx context.Context) {
+	s.wsHandler.Shutdown(ctx)
+	if s.store != nil {
+		if err := s.store.Close(); err != nil {
+			log.Printf("store: 关闭数据库失败: %v", err)
+		}
+	}
+}
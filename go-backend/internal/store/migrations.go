@@ -0,0 +1,67 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations are applied in order, once each, tracked in the
+// schema_migrations table. Add new entries to the end of this slice;
+// never edit an already-shipped one.
+var migrations = []string{
+	`CREATE TABLE sessions (
+		id         TEXT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	)`,
+	`CREATE TABLE messages (
+		id         TEXT PRIMARY KEY,
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		role       TEXT NOT NULL,
+		content    TEXT NOT NULL,
+		timestamp  INTEGER NOT NULL
+	)`,
+	`CREATE INDEX idx_messages_session_ts ON messages(session_id, timestamp)`,
+	`ALTER TABLE sessions ADD COLUMN user_id TEXT NOT NULL DEFAULT ''`,
+	`CREATE INDEX idx_sessions_user ON sessions(user_id)`,
+	`CREATE TABLE users (
+		id            TEXT PRIMARY KEY,
+		username      TEXT NOT NULL UNIQUE,
+		password_hash TEXT NOT NULL,
+		created_at    INTEGER NOT NULL
+	)`,
+}
+
+// runMigrations brings db's schema up to date, applying only the
+// migrations that haven't run yet.
+func runMigrations(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return fmt.Errorf("store: failed to create schema_migrations: %w", err)
+	}
+
+	var applied int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied); err != nil {
+		return fmt.Errorf("store: failed to read schema_migrations: %w", err)
+	}
+
+	for version := applied; version < len(migrations); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("store: failed to begin migration %d: %w", version, err)
+		}
+		if _, err := tx.Exec(migrations[version]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("store: migration %d failed: %w", version, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("store: failed to record migration %d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("store: failed to commit migration %d: %w", version, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	_ "modernc.org/sqlite" // registers the "sqlite" driver, CGO-free
+
+	"go-open-llm-vtuber/internal/models"
+)
+
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) the SQLite database at
+// path and runs any pending schema migrations before returning.
+func NewSQLiteStore(path string) (Store, error) {
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("store: failed to create database directory: %w", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to open database: %w", err)
+	}
+	// modernc.org/sqlite serializes access at the driver level, so a
+	// single shared connection avoids "database is locked" errors under
+	// concurrent writers.
+	db.SetMaxOpenConns(1)
+
+	if err := runMigrations(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *sqliteStore) CreateSession(ctx context.Context, userID, name string) (*models.Session, error) {
+	now := time.Now().Unix()
+	session := &models.Session{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO sessions (id, user_id, name, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		session.ID, session.UserID, session.Name, session.CreatedAt, session.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to create session: %w", err)
+	}
+	return session, nil
+}
+
+func (s *sqliteStore) AppendMessage(ctx context.Context, userID, sessionID string, msg models.ChatMessage) (models.ChatMessage, error) {
+	if msg.ID == "" {
+		msg.ID = uuid.NewString()
+	}
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().Unix()
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.ChatMessage{}, fmt.Errorf("store: failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE sessions SET updated_at = ? WHERE id = ? AND user_id = ?`, msg.Timestamp, sessionID, userID)
+	if err != nil {
+		return models.ChatMessage{}, fmt.Errorf("store: failed to touch session: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return models.ChatMessage{}, err
+	}
+	if rows == 0 {
+		return models.ChatMessage{}, fmt.Errorf("store: session %q not found", sessionID)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO messages (id, session_id, role, content, timestamp) VALUES (?, ?, ?, ?, ?)`,
+		msg.ID, sessionID, msg.Role, msg.Content, msg.Timestamp)
+	if err != nil {
+		return models.ChatMessage{}, fmt.Errorf("store: failed to append message: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.ChatMessage{}, fmt.Errorf("store: failed to commit message: %w", err)
+	}
+	return msg, nil
+}
+
+func (s *sqliteStore) ListSessions(ctx context.Context, userID string) ([]*models.Session, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, created_at, updated_at FROM sessions WHERE user_id = ? ORDER BY updated_at DESC`,
+		userID)
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*models.Session
+	for rows.Next() {
+		session := &models.Session{}
+		if err := rows.Scan(&session.ID, &session.UserID, &session.Name, &session.CreatedAt, &session.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("store: failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+func (s *sqliteStore) GetSession(ctx context.Context, userID, id string, sinceTimestamp int64) (*models.Session, error) {
+	session := &models.Session{}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, user_id, name, created_at, updated_at FROM sessions WHERE id = ? AND user_id = ?`, id, userID,
+	).Scan(&session.ID, &session.UserID, &session.Name, &session.CreatedAt, &session.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("store: session %q not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to load session: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, role, content, timestamp FROM messages
+		 WHERE session_id = ? AND timestamp > ? ORDER BY timestamp ASC`,
+		id, sinceTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to load messages: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var msg models.ChatMessage
+		if err := rows.Scan(&msg.ID, &msg.Role, &msg.Content, &msg.Timestamp); err != nil {
+			return nil, fmt.Errorf("store: failed to scan message: %w", err)
+		}
+		session.Messages = append(session.Messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *sqliteStore) DeleteSession(ctx context.Context, userID, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return fmt.Errorf("store: failed to delete session: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("store: session %q not found", id)
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM messages WHERE session_id = ?`, id); err != nil {
+		return fmt.Errorf("store: failed to delete session messages: %w", err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) RenameSession(ctx context.Context, userID, id string, name string) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE sessions SET name = ?, updated_at = ? WHERE id = ? AND user_id = ?`, name, time.Now().Unix(), id, userID)
+	if err != nil {
+		return fmt.Errorf("store: failed to rename session: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("store: session %q not found", id)
+	}
+	return nil
+}
+
+func (s *sqliteStore) SearchMessages(ctx context.Context, userID, query string) ([]models.ChatMessage, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT m.id, m.role, m.content, m.timestamp FROM messages m
+		 JOIN sessions s ON s.id = m.session_id
+		 WHERE s.user_id = ? AND m.content LIKE ? ORDER BY m.timestamp DESC LIMIT 100`,
+		userID, "%"+query+"%")
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to search messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []models.ChatMessage
+	for rows.Next() {
+		var msg models.ChatMessage
+		if err := rows.Scan(&msg.ID, &msg.Role, &msg.Content, &msg.Timestamp); err != nil {
+			return nil, fmt.Errorf("store: failed to scan message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+func (s *sqliteStore) CreateUser(ctx context.Context, username, passwordHash string) (*models.User, error) {
+	user := &models.User{
+		ID:           uuid.NewString(),
+		Username:     username,
+		PasswordHash: passwordHash,
+		CreatedAt:    time.Now().Unix(),
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO users (id, username, password_hash, created_at) VALUES (?, ?, ?, ?)`,
+		user.ID, user.Username, user.PasswordHash, user.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to create user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *sqliteStore) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	user := &models.User{}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, username, password_hash, created_at FROM users WHERE username = ?`, username,
+	).Scan(&user.ID, &user.Username, &user.PasswordHash, &user.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("store: user %q not found", username)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to load user: %w", err)
+	}
+	return user, nil
+}
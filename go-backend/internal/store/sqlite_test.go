@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"go-open-llm-vtuber/internal/models"
+)
+
+func newTestStore(t *testing.T) Store {
+	t.Helper()
+	s, err := NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestAppendMessageAndGetSessionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	session, err := s.CreateSession(ctx, "user-1", "my chat")
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	if _, err := s.AppendMessage(ctx, "user-1", session.ID, models.ChatMessage{Role: "user", Content: "hello"}); err != nil {
+		t.Fatalf("AppendMessage: %v", err)
+	}
+
+	got, err := s.GetSession(ctx, "user-1", session.ID, 0)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if len(got.Messages) != 1 || got.Messages[0].Content != "hello" {
+		t.Fatalf("Messages = %+v, want one message with content %q", got.Messages, "hello")
+	}
+}
+
+func TestAppendMessageScopesSessionToOwningUser(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	session, err := s.CreateSession(ctx, "user-1", "my chat")
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	if _, err := s.AppendMessage(ctx, "user-2", session.ID, models.ChatMessage{Role: "user", Content: "hijack"}); err == nil {
+		t.Fatal("AppendMessage as a different user = nil error, want not-found")
+	}
+}
+
+func TestGetSessionScopesToOwningUser(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	session, err := s.CreateSession(ctx, "user-1", "my chat")
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	if _, err := s.GetSession(ctx, "user-2", session.ID, 0); err == nil {
+		t.Fatal("GetSession as a different user = nil error, want not-found")
+	}
+}
+
+func TestDeleteSessionRemovesItsMessages(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	session, err := s.CreateSession(ctx, "user-1", "my chat")
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if _, err := s.AppendMessage(ctx, "user-1", session.ID, models.ChatMessage{Role: "user", Content: "hello"}); err != nil {
+		t.Fatalf("AppendMessage: %v", err)
+	}
+
+	if err := s.DeleteSession(ctx, "user-1", session.ID); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+
+	if _, err := s.GetSession(ctx, "user-1", session.ID, 0); err == nil {
+		t.Fatal("GetSession after DeleteSession = nil error, want not-found")
+	}
+
+	msgs, err := s.SearchMessages(ctx, "user-1", "hello")
+	if err != nil {
+		t.Fatalf("SearchMessages: %v", err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("SearchMessages after DeleteSession = %+v, want none", msgs)
+	}
+}
+
+func TestDeleteSessionNotFound(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	if err := s.DeleteSession(ctx, "user-1", "missing"); err == nil {
+		t.Fatal("DeleteSession on a missing session = nil error, want not-found")
+	}
+}
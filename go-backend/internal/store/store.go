@@ -0,0 +1,48 @@
+// Package store persists chat sessions and their messages so a browser
+// refresh (or a reconnect after a network blip) doesn't lose context.
+package store
+
+import (
+	"context"
+
+	"go-open-llm-vtuber/internal/models"
+)
+
+// Store is the persistence boundary for users, sessions and chat
+// history. The SQLite implementation in this package is the only one
+// today, but callers should depend on this interface so a future
+// Postgres/MySQL backend is a drop-in. Every session and message method
+// is scoped by userID so tenants can't see or modify each other's data.
+type Store interface {
+	// CreateSession starts a new, empty session owned by userID with the
+	// given display name and returns it.
+	CreateSession(ctx context.Context, userID, name string) (*models.Session, error)
+	// AppendMessage persists msg under sessionID, assigning an ID and
+	// timestamp if msg doesn't already carry them, and returns the
+	// stored message. It fails if sessionID isn't owned by userID.
+	AppendMessage(ctx context.Context, userID, sessionID string, msg models.ChatMessage) (models.ChatMessage, error)
+	// ListSessions returns userID's sessions' metadata, most recently
+	// updated first, without loading their messages.
+	ListSessions(ctx context.Context, userID string) ([]*models.Session, error)
+	// GetSession loads one of userID's sessions plus its messages with a
+	// timestamp greater than sinceTimestamp (0 returns the full
+	// history), for use by both the REST API and history_replay.
+	GetSession(ctx context.Context, userID, id string, sinceTimestamp int64) (*models.Session, error)
+	// DeleteSession removes one of userID's sessions and all of its
+	// messages.
+	DeleteSession(ctx context.Context, userID, id string) error
+	// RenameSession updates one of userID's sessions' display name.
+	RenameSession(ctx context.Context, userID, id string, name string) error
+	// SearchMessages does a substring search over message content across
+	// all of userID's sessions.
+	SearchMessages(ctx context.Context, userID, query string) ([]models.ChatMessage, error)
+
+	// CreateUser registers a new account with the given username and
+	// bcrypt password hash.
+	CreateUser(ctx context.Context, username, passwordHash string) (*models.User, error)
+	// GetUserByUsername looks up an account by username, for login.
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+
+	// Close releases the underlying database handle.
+	Close() error
+}
@@ -0,0 +1,85 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+func init() {
+	Register("http-api", newHTTPAPISynthesizer)
+}
+
+// httpAPIConfig is the driver-specific config for the "http-api" provider,
+// a generic synchronous TTS endpoint (Edge TTS, ElevenLabs, etc. all fit
+// this shape closely enough behind a thin adapter).
+type httpAPIConfig struct {
+	URL    string `mapstructure:"url"`
+	APIKey string `mapstructure:"api_key"`
+}
+
+type httpAPISynthesizer struct {
+	cfg    httpAPIConfig
+	client *http.Client
+}
+
+func newHTTPAPISynthesizer(raw map[string]interface{}) (Synthesizer, error) {
+	var cfg httpAPIConfig
+	if v, ok := raw["url"].(string); ok {
+		cfg.URL = v
+	}
+	if v, ok := raw["api_key"].(string); ok {
+		cfg.APIKey = v
+	}
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("tts: http-api driver requires a url")
+	}
+	return &httpAPISynthesizer{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+func (h *httpAPISynthesizer) Name() string { return "http-api" }
+
+type httpAPIRequest struct {
+	Text  string `json:"text"`
+	Voice string `json:"voice"`
+}
+
+func (h *httpAPISynthesizer) SynthesizeStream(ctx context.Context, text string, voice string) (<-chan AudioChunk, error) {
+	body, err := json.Marshal(httpAPIRequest{Text: text, Voice: voice})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.cfg.APIKey)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tts: http-api returned status %d", resp.StatusCode)
+	}
+
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan AudioChunk, 1)
+	out <- AudioChunk{Data: audio}
+	close(out)
+	return out, nil
+}
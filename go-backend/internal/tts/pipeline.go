@@ -0,0 +1,147 @@
+package tts
+
+import (
+	"context"
+	"sync"
+)
+
+// EventType identifies the kind of Event a Pipeline emits.
+type EventType string
+
+const (
+	EventSentenceBegin EventType = "tts-sentence-begin"
+	EventSentenceEnd   EventType = "tts-sentence-end"
+	EventAudioChunk    EventType = "tts-audio-chunk"
+)
+
+// Event is one item of Pipeline output, matching one of the WebSocket
+// message types listed above.
+type Event struct {
+	Type  EventType
+	Text  string // sentence text, set on SentenceBegin/SentenceEnd
+	Audio []byte // audio payload, set on AudioChunk
+}
+
+// Pipeline consumes an LLM token stream, splits it into sentences, and
+// synthesizes+plays them back in order while overlapping synthesis of
+// later sentences with playback of earlier ones.
+type Pipeline struct {
+	synth Synthesizer
+	voice string
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// NewPipeline builds a Pipeline around the given Synthesizer.
+func NewPipeline(synth Synthesizer, voice string) *Pipeline {
+	return &Pipeline{synth: synth, voice: voice}
+}
+
+// sentenceFuture carries the result of synthesizing one sentence so the
+// emitter goroutine can wait on sentences in order while synthesis runs
+// concurrently in the background.
+type sentenceFuture struct {
+	text   string
+	chunks chan []byte
+}
+
+// Run consumes tokens until the channel closes or ctx is canceled,
+// returning a channel of Events in sentence order. The returned channel
+// is closed once the last sentence has finished playing back.
+func (p *Pipeline) Run(ctx context.Context, tokens <-chan string) <-chan Event {
+	ctx, cancel := context.WithCancel(ctx)
+	p.mu.Lock()
+	p.cancel = cancel
+	p.mu.Unlock()
+
+	futures := make(chan *sentenceFuture, 16)
+	events := make(chan Event, 16)
+
+	// Producer: split tokens into sentences and kick off synthesis for
+	// each one concurrently, handing an ordered future to the emitter.
+	go func() {
+		defer close(futures)
+		splitter := &sentenceSplitter{}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case token, ok := <-tokens:
+				if !ok {
+					if rest := splitter.Flush(); rest != "" {
+						p.dispatch(ctx, rest, futures)
+					}
+					return
+				}
+				for _, sentence := range splitter.Feed(token) {
+					p.dispatch(ctx, sentence, futures)
+				}
+			}
+		}
+	}()
+
+	// Emitter: drain futures strictly in order, so sentence N+2 may
+	// finish synthesizing before sentence N+1 but still plays after it.
+	go func() {
+		defer close(events)
+		for future := range futures {
+			select {
+			case <-ctx.Done():
+				return
+			case events <- Event{Type: EventSentenceBegin, Text: future.text}:
+			}
+			for chunk := range future.chunks {
+				select {
+				case <-ctx.Done():
+					return
+				case events <- Event{Type: EventAudioChunk, Audio: chunk}:
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case events <- Event{Type: EventSentenceEnd, Text: future.text}:
+			}
+		}
+	}()
+
+	return events
+}
+
+// dispatch starts synthesizing sentence in the background and enqueues
+// its future for the emitter to wait on.
+func (p *Pipeline) dispatch(ctx context.Context, sentence string, futures chan<- *sentenceFuture) {
+	future := &sentenceFuture{text: sentence, chunks: make(chan []byte, 8)}
+
+	select {
+	case futures <- future:
+	case <-ctx.Done():
+		return
+	}
+
+	go func() {
+		defer close(future.chunks)
+		source, err := p.synth.SynthesizeStream(ctx, sentence, p.voice)
+		if err != nil {
+			return
+		}
+		for chunk := range source {
+			select {
+			case future.chunks <- chunk.Data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Interrupt cancels the current Run, draining and discarding anything
+// still in flight so the VTuber can be barged in on mid-sentence.
+func (p *Pipeline) Interrupt() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
@@ -0,0 +1,108 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+func init() {
+	Register("piper", newPiperSynthesizer)
+}
+
+// piperConfig is the driver-specific config for the "piper" provider.
+type piperConfig struct {
+	BinaryPath string `mapstructure:"binary_path"`
+	ModelPath  string `mapstructure:"model_path"`
+	FFmpegPath string `mapstructure:"ffmpeg_path"`
+}
+
+// piperSynthesizer spawns the local `piper` binary per sentence, pipes
+// the text to its stdin, reads raw PCM from stdout, and encodes it to MP3
+// with ffmpeg before handing it back as a single audio chunk.
+type piperSynthesizer struct {
+	cfg piperConfig
+}
+
+func newPiperSynthesizer(raw map[string]interface{}) (Synthesizer, error) {
+	cfg := piperConfig{BinaryPath: "piper", FFmpegPath: "ffmpeg"}
+	if v, ok := raw["binary_path"].(string); ok && v != "" {
+		cfg.BinaryPath = v
+	}
+	if v, ok := raw["model_path"].(string); ok {
+		cfg.ModelPath = v
+	}
+	if v, ok := raw["ffmpeg_path"].(string); ok && v != "" {
+		cfg.FFmpegPath = v
+	}
+	if cfg.ModelPath == "" {
+		return nil, fmt.Errorf("tts: piper driver requires a model_path")
+	}
+	return &piperSynthesizer{cfg: cfg}, nil
+}
+
+func (p *piperSynthesizer) Name() string { return "piper" }
+
+func (p *piperSynthesizer) SynthesizeStream(ctx context.Context, text string, voice string) (<-chan AudioChunk, error) {
+	pcm, err := p.runPiper(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	mp3, err := p.encodeMP3(ctx, pcm)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan AudioChunk, 1)
+	out <- AudioChunk{Data: mp3}
+	close(out)
+	return out, nil
+}
+
+// runPiper spawns the piper binary, writes text to its stdin, and
+// collects the raw 16-bit PCM it writes to stdout.
+func (p *piperSynthesizer) runPiper(ctx context.Context, text string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, p.cfg.BinaryPath, "--model", p.cfg.ModelPath, "--output-raw")
+	cmd.Stdin = bytes.NewBufferString(text)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("tts: piper failed: %w (%s)", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// encodeMP3 pipes raw PCM through ffmpeg and returns the resulting MP3
+// bytes.
+func (p *piperSynthesizer) encodeMP3(ctx context.Context, pcm []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, p.cfg.FFmpegPath,
+		"-f", "s16le", "-ar", "22050", "-ac", "1", "-i", "pipe:0",
+		"-f", "mp3", "pipe:1",
+	)
+	cmd.Stdin = bytes.NewReader(pcm)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("tts: ffmpeg start failed: %w", err)
+	}
+	encoded, err := io.ReadAll(stdout)
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("tts: ffmpeg failed: %w (%s)", err, stderr.String())
+	}
+	return encoded, nil
+}
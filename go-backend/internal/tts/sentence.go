@@ -0,0 +1,52 @@
+package tts
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// sentenceBoundaries are the punctuation runes (ASCII and full-width
+// Chinese/Japanese) that end a sentence worth sending to the synthesizer
+// on its own.
+const sentenceBoundaries = ".!?。！？\n"
+
+// sentenceSplitter accumulates streamed text tokens and yields complete
+// sentences as soon as a boundary is seen, keeping the unterminated
+// remainder buffered for the next call.
+type sentenceSplitter struct {
+	buf strings.Builder
+}
+
+// Feed appends a token and returns any complete sentences it produced.
+func (s *sentenceSplitter) Feed(token string) []string {
+	s.buf.WriteString(token)
+
+	var sentences []string
+	for {
+		text := s.buf.String()
+		idx := strings.IndexAny(text, sentenceBoundaries)
+		if idx < 0 {
+			break
+		}
+		// idx is a byte offset into text; the boundary runes include
+		// full-width CJK punctuation, which is multi-byte in UTF-8, so
+		// the split point is idx plus that rune's own width, not idx+1.
+		_, width := utf8.DecodeRuneInString(text[idx:])
+		sentence := strings.TrimSpace(text[:idx+width])
+		rest := text[idx+width:]
+		s.buf.Reset()
+		s.buf.WriteString(rest)
+		if sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+	return sentences
+}
+
+// Flush returns whatever unterminated text remains buffered, e.g. because
+// the token stream ended without trailing punctuation.
+func (s *sentenceSplitter) Flush() string {
+	rest := strings.TrimSpace(s.buf.String())
+	s.buf.Reset()
+	return rest
+}
@@ -0,0 +1,30 @@
+package tts
+
+import "testing"
+
+func TestSentenceSplitterFeedCJKBoundary(t *testing.T) {
+	var s sentenceSplitter
+
+	sentences := s.Feed("你好。你好吗")
+	if len(sentences) != 1 {
+		t.Fatalf("expected 1 sentence, got %d: %v", len(sentences), sentences)
+	}
+	if got, want := sentences[0], "你好。"; got != want {
+		t.Fatalf("sentence = %q, want %q", got, want)
+	}
+	if rest := s.Flush(); rest != "你好吗" {
+		t.Fatalf("buffered remainder = %q, want %q", rest, "你好吗")
+	}
+}
+
+func TestSentenceSplitterFeedASCIIBoundary(t *testing.T) {
+	var s sentenceSplitter
+
+	sentences := s.Feed("Hello there. How are you")
+	if len(sentences) != 1 || sentences[0] != "Hello there." {
+		t.Fatalf("unexpected sentences: %v", sentences)
+	}
+	if rest := s.Flush(); rest != "How are you" {
+		t.Fatalf("buffered remainder = %q, want %q", rest, "How are you")
+	}
+}
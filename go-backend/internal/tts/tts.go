@@ -0,0 +1,55 @@
+// Package tts provides a pluggable streaming text-to-speech subsystem.
+//
+// A Synthesizer turns one sentence of text into a stream of encoded audio
+// chunks. Pipeline sits on top of it to split LLM output into sentences,
+// synthesize them concurrently, and play them back in the original order.
+package tts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// AudioChunk is one piece of encoded audio (MP3 or Opus, depending on the
+// driver) belonging to a single sentence.
+type AudioChunk struct {
+	Data []byte
+}
+
+// Synthesizer turns text into a stream of audio chunks.
+type Synthesizer interface {
+	// SynthesizeStream starts synthesizing text and returns a channel the
+	// resulting audio chunks are delivered on. The channel is closed once
+	// synthesis of this sentence finishes or ctx is canceled.
+	SynthesizeStream(ctx context.Context, text string, voice string) (<-chan AudioChunk, error)
+	// Name returns the driver name as used in TTSConfig.Provider.
+	Name() string
+}
+
+// Factory builds a Synthesizer from the driver-specific config map pulled
+// out of TTSConfig.
+type Factory func(cfg map[string]interface{}) (Synthesizer, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register adds a driver factory under the given provider name.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// New builds the Synthesizer registered for provider.
+func New(provider string, cfg map[string]interface{}) (Synthesizer, error) {
+	registryMu.RLock()
+	factory, ok := registry[provider]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("tts: unknown provider %q", provider)
+	}
+	return factory(cfg)
+}
@@ -0,0 +1,128 @@
+package vad
+
+// FrameClassifier decides whether a single frame of audio is speech.
+// Drivers implement this and let endpointer own the ring buffer and
+// hangover/coalescing state machine.
+type FrameClassifier interface {
+	IsSpeech(frame []byte) (bool, error)
+}
+
+// endpointer turns a raw per-frame speech/non-speech classifier into a
+// Detector with pre-roll buffering and min-speech/min-silence debouncing.
+type endpointer struct {
+	classifier FrameClassifier
+	cfg        Config
+
+	ring       [][]byte
+	ringBytes  int
+	prerollCap int
+	speaking   bool
+	speechMs   int
+	silenceMs  int
+}
+
+func newEndpointer(classifier FrameClassifier, cfg Config) *endpointer {
+	return &endpointer{
+		classifier: classifier,
+		cfg:        cfg,
+		prerollCap: cfg.PrerollMs,
+	}
+}
+
+func (e *endpointer) Reset() {
+	e.ring = nil
+	e.ringBytes = 0
+	e.speaking = false
+	e.speechMs = 0
+	e.silenceMs = 0
+}
+
+// Close releases the underlying classifier, if it holds resources worth
+// releasing (e.g. an ONNX runtime session); classifiers that don't need
+// cleanup simply don't implement classifierCloser.
+func (e *endpointer) Close() error {
+	if c, ok := e.classifier.(classifierCloser); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// classifierCloser is implemented by FrameClassifiers that hold
+// resources needing explicit release, such as an ONNX runtime session.
+type classifierCloser interface {
+	Close() error
+}
+
+func (e *endpointer) Process(frame []byte) ([]Event, error) {
+	isSpeech, err := e.classifier.IsSpeech(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	frameMs := e.cfg.FrameDurationMs
+	if frameMs <= 0 {
+		frameMs = 20
+	}
+
+	if !e.speaking {
+		e.pushPreroll(frame)
+	}
+
+	if isSpeech {
+		e.silenceMs = 0
+		e.speechMs += frameMs
+		if !e.speaking && e.speechMs >= e.cfg.MinSpeechMs {
+			e.speaking = true
+			// The trigger frame was buffered above; leave it out of the
+			// preroll, since the caller forwards the trigger frame itself.
+			e.ring = e.ring[:len(e.ring)-1]
+			e.ringBytes -= len(frame)
+			events = append(events, Event{Type: EventSpeechStart, Preroll: e.drainPreroll()})
+		}
+	} else {
+		e.speechMs = 0
+		if e.speaking {
+			e.silenceMs += frameMs
+			if e.silenceMs >= e.cfg.MinSilenceMs {
+				e.speaking = false
+				e.silenceMs = 0
+				events = append(events, Event{Type: EventSpeechEnd})
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// pushPreroll appends frame to the ring buffer, evicting the oldest
+// frames so that at most PrerollMs of audio precedes the newest frame.
+func (e *endpointer) pushPreroll(frame []byte) {
+	e.ring = append(e.ring, frame)
+	e.ringBytes += len(frame)
+
+	frameMs := e.cfg.FrameDurationMs
+	if frameMs <= 0 {
+		frameMs = 20
+	}
+	maxFrames := e.prerollCap/frameMs + 1
+	if maxFrames < 1 {
+		maxFrames = 1
+	}
+	for len(e.ring) > maxFrames {
+		e.ringBytes -= len(e.ring[0])
+		e.ring = e.ring[1:]
+	}
+}
+
+// drainPreroll concatenates and clears the ring buffer, returning the
+// audio that should be prepended to the newly opened ASR session.
+func (e *endpointer) drainPreroll() []byte {
+	out := make([]byte, 0, e.ringBytes)
+	for _, chunk := range e.ring {
+		out = append(out, chunk...)
+	}
+	e.ring = nil
+	e.ringBytes = 0
+	return out
+}
@@ -0,0 +1,79 @@
+package vad
+
+import "testing"
+
+// scriptedClassifier replays a fixed sequence of IsSpeech results, one per
+// Process call.
+type scriptedClassifier struct {
+	script []bool
+	i      int
+}
+
+func (c *scriptedClassifier) IsSpeech(frame []byte) (bool, error) {
+	v := c.script[c.i]
+	c.i++
+	return v, nil
+}
+
+func TestEndpointerDebouncesSpeechStartAndEnd(t *testing.T) {
+	cfg := Config{
+		MinSpeechMs:     40,
+		MinSilenceMs:    40,
+		PrerollMs:       20,
+		FrameDurationMs: 20,
+	}
+	// 1 speech frame isn't enough to cross MinSpeechMs (40ms); 2 are.
+	classifier := &scriptedClassifier{script: []bool{false, true, true, false, false}}
+	e := newEndpointer(classifier, cfg)
+
+	var gotEvents []EventType
+	for range classifier.script {
+		events, err := e.Process(make([]byte, 4))
+		if err != nil {
+			t.Fatalf("Process: %v", err)
+		}
+		for _, ev := range events {
+			gotEvents = append(gotEvents, ev.Type)
+		}
+	}
+
+	want := []EventType{EventSpeechStart, EventSpeechEnd}
+	if len(gotEvents) != len(want) {
+		t.Fatalf("events = %v, want %v", gotEvents, want)
+	}
+	for i, w := range want {
+		if gotEvents[i] != w {
+			t.Fatalf("events = %v, want %v", gotEvents, want)
+		}
+	}
+}
+
+func TestEndpointerPrerollCarriesPrecedingAudio(t *testing.T) {
+	cfg := Config{
+		MinSpeechMs:     20,
+		MinSilenceMs:    20,
+		PrerollMs:       40,
+		FrameDurationMs: 20,
+	}
+	classifier := &scriptedClassifier{script: []bool{false, true}}
+	e := newEndpointer(classifier, cfg)
+
+	preSilence := []byte{1, 2, 3, 4}
+	triggerFrame := []byte{5, 6, 7, 8}
+	if _, err := e.Process(preSilence); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	events, err := e.Process(triggerFrame)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != EventSpeechStart {
+		t.Fatalf("events = %v, want a single speech-start", events)
+	}
+	// Preroll carries only the audio preceding the trigger frame; the
+	// triggering frame itself is not buffered.
+	want := append([]byte{}, preSilence...)
+	if string(events[0].Preroll) != string(want) {
+		t.Fatalf("preroll = %v, want %v", events[0].Preroll, want)
+	}
+}
@@ -0,0 +1,90 @@
+package vad
+
+import "math"
+
+func init() {
+	Register("energy", newEnergyDetector)
+}
+
+// energyClassifier is a dependency-free speech/non-speech classifier
+// based on short-term frame energy and zero-crossing rate, used as the
+// baseline driver when no ONNX runtime is available.
+type energyClassifier struct {
+	// energyThreshold is derived from Aggressiveness: more aggressive
+	// settings require louder frames. zcrMaxRate is a fixed upper bound
+	// on the zero-crossing rate, the same for every aggressiveness.
+	energyThreshold float64
+	zcrMaxRate      float64
+}
+
+func newEnergyDetector(cfg Config) (Detector, error) {
+	classifier := &energyClassifier{
+		energyThreshold: aggressivenessToEnergyThreshold(cfg.Aggressiveness),
+		zcrMaxRate:      0.4,
+	}
+	return newEndpointer(classifier, cfg), nil
+}
+
+// aggressivenessToEnergyThreshold maps the standard 0-3 aggressiveness
+// scale onto an RMS threshold on a normalized [0,1] PCM16 sample scale.
+func aggressivenessToEnergyThreshold(aggressiveness int) float64 {
+	switch {
+	case aggressiveness <= 0:
+		return 0.01
+	case aggressiveness == 1:
+		return 0.02
+	case aggressiveness == 2:
+		return 0.035
+	default:
+		return 0.05
+	}
+}
+
+func (c *energyClassifier) IsSpeech(frame []byte) (bool, error) {
+	samples := decodePCM16(frame)
+	if len(samples) == 0 {
+		return false, nil
+	}
+
+	energy := rmsEnergy(samples)
+	if energy < c.energyThreshold {
+		return false, nil
+	}
+
+	// Broadband noise (e.g. fans, hiss) tends to have a much higher
+	// zero-crossing rate than voiced speech, so frames above zcrMaxRate
+	// are rejected even when they are loud enough.
+	zcr := zeroCrossingRate(samples)
+	return zcr <= c.zcrMaxRate, nil
+}
+
+func decodePCM16(frame []byte) []int16 {
+	n := len(frame) / 2
+	samples := make([]int16, n)
+	for i := 0; i < n; i++ {
+		samples[i] = int16(uint16(frame[2*i]) | uint16(frame[2*i+1])<<8)
+	}
+	return samples
+}
+
+func rmsEnergy(samples []int16) float64 {
+	var sum float64
+	for _, s := range samples {
+		normalized := float64(s) / math.MaxInt16
+		sum += normalized * normalized
+	}
+	return math.Sqrt(sum / float64(len(samples)))
+}
+
+func zeroCrossingRate(samples []int16) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	crossings := 0
+	for i := 1; i < len(samples); i++ {
+		if (samples[i-1] >= 0) != (samples[i] >= 0) {
+			crossings++
+		}
+	}
+	return float64(crossings) / float64(len(samples)-1)
+}
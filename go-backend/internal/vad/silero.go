@@ -0,0 +1,115 @@
+package vad
+
+import (
+	"fmt"
+	"sync"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+func init() {
+	Register("silero-onnx", newSileroDetector)
+}
+
+// sileroModelPath is the fixed location the Silero model is loaded from;
+// neither VADConfig nor vad.Config carries a model path, so it cannot be
+// changed through configuration.
+const sileroModelPath = "configs/models/silero_vad.onnx"
+
+var (
+	ortInitOnce sync.Once
+	ortInitErr  error
+)
+
+// sileroClassifier runs the Silero VAD ONNX model on each frame. The
+// model expects 16kHz mono PCM and returns a single speech probability.
+type sileroClassifier struct {
+	session   *ort.AdvancedSession
+	input     *ort.Tensor[float32]
+	output    *ort.Tensor[float32]
+	threshold float32
+}
+
+func newSileroDetector(cfg Config) (Detector, error) {
+	ortInitOnce.Do(func() {
+		ortInitErr = ort.InitializeEnvironment()
+	})
+	if ortInitErr != nil {
+		return nil, fmt.Errorf("vad: failed to initialize onnxruntime: %w", ortInitErr)
+	}
+
+	frameSamples := cfg.SampleRate * cfg.FrameDurationMs / 1000
+	if frameSamples <= 0 {
+		frameSamples = cfg.SampleRate / 50 // default to 20ms frames
+	}
+
+	input, err := ort.NewEmptyTensor[float32](ort.NewShape(1, int64(frameSamples)))
+	if err != nil {
+		return nil, fmt.Errorf("vad: failed to allocate input tensor: %w", err)
+	}
+	output, err := ort.NewEmptyTensor[float32](ort.NewShape(1, 1))
+	if err != nil {
+		input.Destroy()
+		return nil, fmt.Errorf("vad: failed to allocate output tensor: %w", err)
+	}
+
+	session, err := ort.NewAdvancedSession(sileroModelPath,
+		[]string{"input"}, []string{"output"},
+		[]ort.Value{input}, []ort.Value{output}, nil)
+	if err != nil {
+		input.Destroy()
+		output.Destroy()
+		return nil, fmt.Errorf("vad: failed to load silero model at %s: %w", sileroModelPath, err)
+	}
+
+	classifier := &sileroClassifier{
+		session:   session,
+		input:     input,
+		output:    output,
+		threshold: aggressivenessToSileroThreshold(cfg.Aggressiveness),
+	}
+	return newEndpointer(classifier, cfg), nil
+}
+
+func aggressivenessToSileroThreshold(aggressiveness int) float32 {
+	switch {
+	case aggressiveness <= 0:
+		return 0.3
+	case aggressiveness == 1:
+		return 0.45
+	case aggressiveness == 2:
+		return 0.6
+	default:
+		return 0.75
+	}
+}
+
+// Close releases the ONNX runtime session and its input/output tensors.
+// It's picked up by endpointer.Close via the classifierCloser interface.
+func (c *sileroClassifier) Close() error {
+	if err := c.session.Destroy(); err != nil {
+		return fmt.Errorf("vad: failed to destroy silero session: %w", err)
+	}
+	c.input.Destroy()
+	c.output.Destroy()
+	return nil
+}
+
+func (c *sileroClassifier) IsSpeech(frame []byte) (bool, error) {
+	samples := decodePCM16(frame)
+	dst := c.input.GetData()
+	for i := range dst {
+		if i < len(samples) {
+			dst[i] = float32(samples[i]) / 32768.0
+		} else {
+			dst[i] = 0
+		}
+	}
+
+	if err := c.session.Run(); err != nil {
+		return false, fmt.Errorf("vad: silero inference failed: %w", err)
+	}
+
+	prob := c.output.GetData()[0]
+	return prob >= c.threshold, nil
+}
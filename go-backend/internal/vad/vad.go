@@ -0,0 +1,93 @@
+// Package vad provides server-side voice activity detection with
+// endpointing, used to drive turn-taking: it watches the PCM frames
+// coming off the WebSocket and tells the caller when a user starts and
+// stops talking.
+package vad
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Config holds the tunables for an endpointing session, taken directly
+// from VADConfig plus whatever the chosen driver needs.
+type Config struct {
+	// Aggressiveness ranges 0-3; higher values require stronger evidence
+	// of speech before triggering, trading latency for false positives.
+	Aggressiveness int
+	// MinSpeechMs is how long speech must persist before a speech-start
+	// event fires, filtering out clicks and other transients.
+	MinSpeechMs int
+	// MinSilenceMs is how long silence must persist before a
+	// speech-end event fires. Gaps shorter than this are treated as
+	// part of the same utterance.
+	MinSilenceMs int
+	// PrerollMs is how much audio preceding the trigger frame is kept
+	// in the ring buffer and prepended to the ASR stream once speech is
+	// confirmed.
+	PrerollMs int
+	// SampleRate is the PCM sample rate of incoming frames, in Hz.
+	SampleRate int
+	// FrameDurationMs is the duration represented by one frame passed to
+	// Process; the detector is only defined for 10/20/30ms frames.
+	FrameDurationMs int
+}
+
+// EventType identifies the kind of Event a Detector emits.
+type EventType string
+
+const (
+	EventSpeechStart EventType = "vad-speech-start"
+	EventSpeechEnd   EventType = "vad-speech-end"
+)
+
+// Event is one speech-start or speech-end transition.
+type Event struct {
+	Type EventType
+	// Preroll carries the buffered audio immediately preceding a
+	// speech-start event, so the ASR session can be opened with it
+	// already prepended.
+	Preroll []byte
+}
+
+// Detector watches a stream of PCM frames for one connection and emits
+// speech-start/speech-end events with pre-roll and silence coalescing
+// already applied.
+type Detector interface {
+	// Process consumes one frame of raw PCM16 audio and returns any
+	// events it triggered.
+	Process(frame []byte) ([]Event, error)
+	// Reset clears all internal state, used when starting a fresh
+	// utterance on the same connection.
+	Reset()
+	// Close releases any underlying model session or other resources
+	// held by the detector. Called once, when the connection it belongs
+	// to closes.
+	Close() error
+}
+
+// Factory builds a Detector from a Config.
+type Factory func(cfg Config) (Detector, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register adds a driver factory under the given provider name.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// New builds the Detector registered for provider.
+func New(provider string, cfg Config) (Detector, error) {
+	registryMu.RLock()
+	factory, ok := registry[provider]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("vad: unknown provider %q", provider)
+	}
+	return factory(cfg)
+}